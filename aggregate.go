@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/colussim/GoLC/pkg/report"
+	"github.com/olekukonko/tablewriter"
+)
+
+func aggregateCommand(args []string) {
+	fs := newFlagSet("aggregate", "<results> <results>...", "Merge the results directories of several platforms and organizations in one global report")
+	outputFlag := fs.String("output", "Aggregated", "Directory of the aggregated results")
+	onExistingFlag := fs.String("on-existing", "", "What to do if the output directory exists: <backup>||<overwrite>||<fail>||<timestamp>")
+	yesFlag := fs.Bool("yes", false, "Do not prompt, assume yes (same as -on-existing backup when not set)")
+
+	directories := parseArgs(fs, args)
+	if len(directories) < 2 {
+		fs.Usage()
+		os.Exit(1)
+	}
+
+	output, _ := filepath.Abs(*outputFlag)
+	for _, directory := range directories {
+		if path, _ := filepath.Abs(directory); path == output {
+			fmt.Printf("❌ The output directory <'%s'> is aggregated, choose another one with -output\n", *outputFlag)
+			os.Exit(1)
+		}
+	}
+
+	switch *onExistingFlag {
+	case "", onExistingBackup, onExistingOverwrite, onExistingFail, onExistingTimestamp:
+	default:
+		fmt.Printf("❌ Invalid -on-existing value '%s' : <backup>||<overwrite>||<fail>||<timestamp>\n", *onExistingFlag)
+		os.Exit(1)
+	}
+	onExisting := *onExistingFlag
+	if onExisting == "" && *yesFlag {
+		onExisting = onExistingBackup
+	}
+	dir, err := prepareResultsDir(*outputFlag, onExisting)
+	if err != nil {
+		fmt.Println("❌", err)
+		os.Exit(1)
+	}
+
+	aggregate, err := report.Aggregate(dir, directories)
+	if err != nil {
+		fmt.Println("❌ Error aggregating results:", err)
+		os.Exit(1)
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Platform", "Organization", "Repositories", "Duplicates", "Code lines"})
+	table.SetBorder(false)
+	table.SetAutoFormatHeaders(false)
+	for _, source := range aggregate.Sources {
+		table.Append([]string{source.Platform, source.Organization, strconv.Itoa(source.Repositories), strconv.Itoa(source.Duplicates), strconv.Itoa(source.CodeLines)})
+	}
+	for _, platform := range aggregate.Platforms {
+		if len(platform.Organizations) < 2 {
+			continue
+		}
+		table.Append([]string{platform.Platform, "Total of " + strings.Join(platform.Organizations, ", "), strconv.Itoa(platform.Repositories), "", strconv.Itoa(platform.CodeLines)})
+	}
+	table.SetFooter([]string{"Total", "", strconv.Itoa(aggregate.Repositories), strconv.Itoa(len(aggregate.Duplicates)), strconv.Itoa(aggregate.CodeLines)})
+	table.Render()
+
+	for _, duplicate := range aggregate.Duplicates {
+		fmt.Printf("❗️ %s is a mirror of %s, counted once\n", duplicate.Repository, duplicate.MirrorOf)
+	}
+
+	fmt.Printf("\n✅ Aggregated results written to <'%s'>, the breakdown is in %s\n", dir, report.AggregateFile)
+	fmt.Printf("✅ run : golc report -results %s -serve\n", dir)
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/colussim/GoLC/assets"
+	"github.com/colussim/GoLC/pkg/goloc"
+	"github.com/colussim/GoLC/pkg/reporter"
+)
+
+// Split a comma separated flag value
+func splitList(value string) []string {
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// Extensions are matched with their leading dot : go -> .go
+func splitExtensions(value string) []string {
+	extensions := splitList(value)
+	for i, ext := range extensions {
+		if !strings.HasPrefix(ext, ".") {
+			extensions[i] = "." + ext
+		}
+	}
+	return extensions
+}
+
+func countCommand(args []string) {
+	fs := newFlagSet("count", "[paths...]", "Count the lines of code of local directories or remote sources, like cloc")
+	byFile := fs.Bool("by-file", false, "Report the results for every source file")
+	excludeDir := fs.String("exclude-dir", "", "Comma separated list of paths to exclude, relative to the scanned path (globs allowed)")
+	excludeExt := fs.String("exclude-ext", "", "Comma separated list of file extensions to exclude")
+	includeExt := fs.String("include-ext", "", "Comma separated list of file extensions to count, all the others are ignored")
+	orderByLang := fs.Bool("order-by-lang", false, "Order the results by language")
+	orderByFile := fs.Bool("order-by-file", false, "Order the results by number of files")
+	orderByCode := fs.Bool("order-by-code", false, "Order the results by code lines")
+	orderByLine := fs.Bool("order-by-line", false, "Order the results by lines")
+	orderByBlank := fs.Bool("order-by-blank", false, "Order the results by blank lines")
+	orderByComment := fs.Bool("order-by-comment", false, "Order the results by comments")
+	order := fs.String("order", "DESC", "Sort order: <ASC>||<DESC>")
+	reportFormats := fs.String("report-formats", "prompt", "Comma separated list of report formats: <"+strings.Join(reporter.Names(), ">||<")+">")
+	outputPath := fs.String("output", ".", "Directory of the json reports")
+	outputName := fs.String("output-name", "Result_", "Prefix of the json report names")
+
+	paths := parseArgs(fs, args)
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+
+	*order = strings.ToUpper(*order)
+	if *order != "ASC" && *order != "DESC" {
+		fmt.Printf("❌ Invalid -order value '%s'. Use ASC or DESC\n", *order)
+		os.Exit(1)
+	}
+
+	for _, path := range paths {
+		params := goloc.Params{
+			Path:              path,
+			ByFile:            *byFile,
+			ExcludePaths:      splitList(*excludeDir),
+			ExcludeExtensions: splitExtensions(*excludeExt),
+			IncludeExtensions: splitExtensions(*includeExt),
+			OrderByLang:       *orderByLang,
+			OrderByFile:       *orderByFile,
+			OrderByCode:       *orderByCode,
+			OrderByLine:       *orderByLine,
+			OrderByBlank:      *orderByBlank,
+			OrderByComment:    *orderByComment,
+			Order:             *order,
+			OutputName:        *outputName,
+			OutputPath:        *outputPath,
+			ReportFormats:     splitList(*reportFormats),
+		}
+
+		if len(paths) > 1 {
+			fmt.Printf("\n🔎 %s\n\n", path)
+		}
+
+		gc, err := goloc.NewGCloc(params, assets.Languages)
+		if err != nil {
+			fmt.Printf("❌ Error analysing %s: %s\n", path, err)
+			os.Exit(1)
+		}
+
+		err = gc.Run()
+		removeExtracted(gc.Repopath, path)
+		if err != nil {
+			fmt.Printf("❌ Error analysing %s: %s\n", path, err)
+			os.Exit(1)
+		}
+	}
+}
+
+// Remove the temporary directory where a remote source was downloaded.
+// A local directory is scanned in place, and a symbolic link of go-getter points to the source itself.
+func removeExtracted(repoPath, path string) {
+	if abs, err := filepath.Abs(path); err == nil && repoPath == abs {
+		return
+	}
+	if !strings.HasPrefix(filepath.Base(repoPath), "gcloc-extract-") {
+		return
+	}
+	if err := os.RemoveAll(repoPath); err != nil {
+		fmt.Printf("❗️ Error deleting temporary directory %s: %s\n", repoPath, err)
+	}
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/colussim/GoLC/assets"
+	"github.com/colussim/GoLC/pkg/diff"
+	"github.com/colussim/GoLC/pkg/report"
+	"github.com/olekukonko/tablewriter"
+)
+
+func diffCommand(args []string) {
+	fs := newFlagSet("diff", "<old> <new>", "Compare the lines of code of two results directories, two Result_*.json files, or two revisions of a git repository with -git")
+	allFlag := fs.Bool("all", false, "Also show the unchanged results")
+	byFlag := fs.String("by", "repo", "Show the changes by repo, language or file (file only with -git)")
+	gitFlag := fs.String("git", "", "Local path or URL of a git repository, <old> and <new> are then two of its branches, tags or commits")
+	jsonFlag := fs.Bool("json", false, "Write the whole comparison as JSON")
+
+	positional := parseArgs(fs, args)
+	requireArgs(fs, positional, 2)
+
+	switch *byFlag {
+	case "repo", "language":
+	case "file":
+		if *gitFlag == "" {
+			fmt.Println("❌ -by file compares two revisions of a repository, use it with -git")
+			os.Exit(1)
+		}
+	default:
+		fmt.Printf("❌ Invalid -by value '%s'. Use repo, language or file\n", *byFlag)
+		os.Exit(1)
+	}
+
+	var result *diff.Report
+	var err error
+	switch {
+	case *gitFlag != "":
+		fmt.Fprintf(os.Stderr, "🔎 Scanning %s and %s of %s ...\n", positional[0], positional[1], *gitFlag)
+		result, err = diff.CompareRefs(context.Background(), *gitFlag, positional[0], positional[1], assets.Languages)
+	case isFile(positional[0]) && isFile(positional[1]):
+		result, err = diff.CompareFiles(positional[0], positional[1])
+	default:
+		result, err = report.CompareDirs(positional[0], positional[1])
+	}
+	if err != nil {
+		fmt.Println("❌ Error comparing results:", err)
+		os.Exit(1)
+	}
+
+	if *jsonFlag {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(result)
+		return
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetBorder(false)
+	table.SetAutoFormatHeaders(false)
+
+	switch *byFlag {
+	case "repo":
+		table.SetHeader([]string{"Repository", "Status", "Old code lines", "New code lines", "Delta"})
+		for _, repo := range result.Repos {
+			if repo.Status != diff.Unchanged || *allFlag {
+				table.Append(changeRow(repo.Change))
+			}
+		}
+	case "language":
+		table.SetHeader([]string{"Language", "Status", "Old code lines", "New code lines", "Delta"})
+		for _, lang := range result.Languages {
+			if lang.Status != diff.Unchanged || *allFlag {
+				table.Append(changeRow(lang))
+			}
+		}
+	case "file":
+		table.SetHeader([]string{"File", "Status", "Old code lines", "New code lines", "Delta", "Language"})
+		for _, file := range result.Files {
+			if file.Status != diff.Unchanged || *allFlag {
+				table.Append(append(changeRow(file.Change), file.Language))
+			}
+		}
+	}
+
+	footer := []string{
+		"Total",
+		"",
+		strconv.Itoa(result.OldTotal),
+		strconv.Itoa(result.NewTotal),
+		fmt.Sprintf("%+d", result.Delta),
+	}
+	if *byFlag == "file" {
+		footer = append(footer, "")
+	}
+	table.SetFooter(footer)
+
+	table.Render()
+}
+
+func changeRow(change diff.Change) []string {
+	return []string{
+		change.Name,
+		change.Status,
+		strconv.Itoa(change.OldLines),
+		strconv.Itoa(change.NewLines),
+		fmt.Sprintf("%+d", change.Delta),
+	}
+}
+
+func isFile(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
@@ -0,0 +1,7 @@
+// Package dist embeds the web assets of the reports
+package dist
+
+import "embed"
+
+//go:embed css/theme.min.css img js vendors/chartjs vendors/fontawesome/css/all.min.css vendors/fontawesome/webfonts
+var FS embed.FS
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/colussim/GoLC/pkg/report"
+)
+
+func exportCommand(args []string) {
+	fs := newFlagSet("export", "", "Write one spreadsheet row per repository and language of a results directory")
+	outputFlag := addOutputFlag(fs)
+	formatFlag := fs.String("format", "csv", "Format of the sheet: <csv>||<tsv>")
+	fileFlag := fs.String("file", "", "Path of the sheet, default <output>/Results.<format>")
+	totalsFlag := fs.Bool("totals", true, "Add a last row with the totals")
+
+	positional := parseArgs(fs, args)
+	requireArgs(fs, positional, 0)
+
+	var comma rune
+	switch *formatFlag {
+	case "csv":
+		comma = ','
+	case "tsv":
+		comma = '\t'
+	default:
+		fmt.Printf("❌ Invalid -format value '%s'. Use csv or tsv\n", *formatFlag)
+		os.Exit(1)
+	}
+
+	path := *fileFlag
+	if path == "" {
+		path = filepath.Join(*outputFlag, "Results."+*formatFlag)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		fmt.Println("❌ Error creating file:", err)
+		os.Exit(1)
+	}
+	defer file.Close()
+
+	if err := report.WriteSheet(file, *outputFlag, comma, *totalsFlag); err != nil {
+		fmt.Println("❌ Error writing sheet:", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("✅ Sheet exported to %s\n", path)
+}
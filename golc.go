@@ -3,28 +3,40 @@ package main
 import (
 	"archive/zip"
 	"bufio"
+	"context"
+	"crypto/sha256"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
-	"log"
+	"net/http"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"runtime"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/briandowns/spinner"
 	"github.com/colussim/GoLC/assets"
+	"github.com/colussim/GoLC/pkg/gogit"
 	"github.com/colussim/GoLC/pkg/goloc"
+	"github.com/colussim/GoLC/pkg/journal"
+	"github.com/colussim/GoLC/pkg/report"
+	"github.com/colussim/GoLC/pkg/retry"
+	"github.com/colussim/GoLC/pkg/scheduler"
 
 	"github.com/colussim/GoLC/pkg/devops/getazure"
 	getbibucket "github.com/colussim/GoLC/pkg/devops/getbitbucket/v2"
 	getbibucketdc "github.com/colussim/GoLC/pkg/devops/getbitbucketdc"
 	"github.com/colussim/GoLC/pkg/devops/getgithub"
 	"github.com/colussim/GoLC/pkg/devops/getgitlab"
+	"github.com/colussim/GoLC/pkg/devops/inventory"
 	"github.com/colussim/GoLC/pkg/utils"
+	"golang.org/x/term"
 )
 
 type OrganizationData struct {
@@ -34,6 +46,8 @@ type OrganizationData struct {
 	LinesOfCodeLargestRepo string `json:"LinesOfCodeLargestRepo"`
 	DevOpsPlatform         string `json:"DevOpsPlatform"`
 	NumberRepos            int    `json:"NumberRepos"`
+	// Subtotals of the projects, or of the groups for Gitlab
+	Projects []report.ProjectSummary `json:"Projects,omitempty"`
 }
 
 type Repository struct {
@@ -73,24 +87,6 @@ type Report struct {
 	Results         interface{}
 }
 
-type Result struct {
-	TotalFiles      int           `json:"TotalFiles"`
-	TotalLines      int           `json:"TotalLines"`
-	TotalBlankLines int           `json:"TotalBlankLines"`
-	TotalComments   int           `json:"TotalComments"`
-	TotalCodeLines  int           `json:"TotalCodeLines"`
-	Results         []LanguageRes `json:"Results"`
-}
-
-type LanguageRes struct {
-	Language   string `json:"Language"`
-	Files      int    `json:"Files"`
-	Lines      int    `json:"Lines"`
-	BlankLines int    `json:"BlankLines"`
-	Comments   int    `json:"Comments"`
-	CodeLines  int    `json:"CodeLines"`
-}
-
 type RepoParams struct {
 	ProjectKey string
 	Namespace  string
@@ -99,11 +95,31 @@ type RepoParams struct {
 	PathToScan string
 }
 
+// Limits of the analysis of one repository
+type repoPolicy struct {
+	Timeout time.Duration
+	Retry   retry.Policy
+}
+
+type repoFailure struct {
+	ProjectKey string `json:"ProjectKey"`
+	Namespace  string `json:"Namespace,omitempty"`
+	RepoSlug   string `json:"RepoSlug"`
+	Branch     string `json:"Branch"`
+	Reason     string `json:"Reason"`
+	Attempts   int    `json:"Attempts"`
+}
+
+const journalFile = "journal.jsonl"
+const failuresFile = "failures.json"
+
 const errorMessageRepo = "\n❌ Error Analyse Repositories: "
 const errorMessageDi = "\r❌ Error deleting Repository Directory: %v\n"
 const errorMessageAnalyse = "\r❌ No Analysis performed...\n"
 const errorMessageRepos = "Error Get Info Repositories in organization '%s' : '%s'"
 const directoryconf = "/config"
+const platformTargets = "<BitBucketSRV>||<BitBucket>||<Github>||<Gitlab>||<Azure>||<File>"
+const version = "1.0.3"
 
 var logFile *os.File
 
@@ -181,8 +197,8 @@ func parseJSONFile(filePath, reponame string) int {
 }
 
 // Create a Bakup File for Result directory
-func createBackup(sourceDir, pwd string) error {
-	backupDir := filepath.Join(pwd, "Saves")
+func createBackup(sourceDir string) error {
+	backupDir := filepath.Join(filepath.Dir(sourceDir), "Saves")
 	backupFilePath := generateBackupFilePath(sourceDir, backupDir)
 
 	if err := createBackupDirectory(backupDir); err != nil {
@@ -206,6 +222,74 @@ func createBackup(sourceDir, pwd string) error {
 	return nil
 }
 
+// Behaviours when the results directory already exists
+const (
+	onExistingBackup    = "backup"
+	onExistingOverwrite = "overwrite"
+	onExistingFail      = "fail"
+	onExistingTimestamp = "timestamp"
+	onExistingKeep      = "keep"
+)
+
+// Ask the user what to do with an existing results directory
+func askOnExisting(dir string) string {
+	fmt.Printf("❗️ Directory <'%s'> already exists. Do you want to delete it? (y/n): ", dir)
+	var response string
+	fmt.Scanln(&response)
+
+	if response != "y" && response != "Y" {
+		return onExistingFail
+	}
+
+	fmt.Printf("❗️ Do you want to create a backup of the directory before deleting? (y/n): ")
+	var backupResponse string
+	fmt.Scanln(&backupResponse)
+
+	if backupResponse == "y" || backupResponse == "Y" {
+		return onExistingBackup
+	}
+	return onExistingOverwrite
+}
+
+// Prepare the results directory according to the on-existing mode and return the directory to use.
+// An empty mode prompts the user, or fails when the input is not a terminal as in CI.
+func prepareResultsDir(dir, mode string) (string, error) {
+	if _, err := os.Stat(dir); err == nil {
+		if mode == "" {
+			if !term.IsTerminal(int(os.Stdin.Fd())) {
+				return "", fmt.Errorf("directory <'%s'> already exists, choose what to do with -on-existing or -yes", dir)
+			}
+			mode = askOnExisting(dir)
+		}
+
+		switch mode {
+		case onExistingBackup:
+			if err := createBackup(dir); err != nil {
+				return "", fmt.Errorf("error creating backup: %s", err)
+			}
+			if err := os.RemoveAll(dir); err != nil {
+				return "", fmt.Errorf("error deleting directory: %s", err)
+			}
+		case onExistingOverwrite:
+			if err := os.RemoveAll(dir); err != nil {
+				return "", fmt.Errorf("error deleting directory: %s", err)
+			}
+		case onExistingTimestamp:
+			dir = fmt.Sprintf("%s_%s", dir, time.Now().Format("2006-01-02_15-04-05"))
+			fmt.Printf("✅ Results will be written to <'%s'>\n", dir)
+		case onExistingFail:
+			return "", fmt.Errorf("directory <'%s'> already exists", dir)
+		}
+	} else if !os.IsNotExist(err) {
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, directoryconf), os.ModePerm); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
 // Generate Backup File Name
 func generateBackupFilePath(sourceDir, backupDir string) string {
 	backupFileName := fmt.Sprintf("%s_%s.zip", filepath.Base(sourceDir), time.Now().Format("2006-01-02_15-04-05"))
@@ -243,7 +327,11 @@ func addFilesToBackup(sourceDir string, zipWriter *zip.Writer) error {
 }
 
 func addFileToZip(filePath, relPath string, fileInfo os.FileInfo, zipWriter *zip.Writer) error {
-	zipFile, err := zipWriter.Create(relPath)
+	name := filepath.ToSlash(relPath)
+	if fileInfo.IsDir() {
+		name += "/"
+	}
+	zipFile, err := zipWriter.Create(name)
 	if err != nil {
 		return err
 	}
@@ -262,126 +350,335 @@ func addFileToZip(filePath, relPath string, fileInfo os.FileInfo, zipWriter *zip
 }
 
 // Generic function to analyze repositories
-func AnalyseReposList(DestinationResult string, platformConfig map[string]interface{}, repolist interface{}, analyseRepoFunc func(project interface{}, DestinationResult string, platformConfig map[string]interface{}, spin *spinner.Spinner, results chan int, count *int)) (cpt int) {
+// Number of repositories analysed at the same time
+func scanWorkers(platformConfig map[string]interface{}) int {
+	// The File platform may have no Multithreading and Workers
+	multithreading, _ := platformConfig["Multithreading"].(bool)
+	workers, _ := platformConfig["Workers"].(float64)
+	if multithreading && workers > 0 {
+		return int(workers)
+	}
+	return 1
+}
+
+// Analyse the repositories with the workers of the configuration, the repositories not analysed when ctx is done are cancelled
+func AnalyseReposList(ctx context.Context, DestinationResult string, platformConfig map[string]interface{}, repolist []RepoParams, jr *journal.Journal, policy repoPolicy) (analysed, failed, cancelled int) {
 	fmt.Print("\n🔎 Analysis of Repos ...\n")
 
-	spin := spinner.New(spinner.CharSets[35], 100*time.Millisecond)
-	spin.Color("green", "bold")
-	messageF := ""
-	spin.FinalMSG = messageF
-
-	// Create a channel to receive results
-	results := make(chan int)
-	count := 1
-
-	if platformConfig["Multithreading"].(bool) {
-		if len(repolist.([]interface{})) > int(platformConfig["NumberWorkerRepos"].(float64)) {
-			// Launch goroutines in batches of X
-			X := int(platformConfig["Workers"].(float64))
-			batches := len(repolist.([]interface{})) / X
-			remainder := len(repolist.([]interface{})) % X
-			for i := 0; i < batches; i++ {
-				for j := i * X; j < (i+1)*X; j++ {
-					go analyseRepoFunc(repolist.([]interface{})[j], DestinationResult, platformConfig, spin, results, &count)
-				}
-				waitForWorkers(X, results)
-			}
-			// Launch remaining goroutines
-			for i := batches * X; i < batches*X+remainder; i++ {
-				go analyseRepoFunc(repolist.([]interface{})[i], DestinationResult, platformConfig, spin, results, &count)
-			}
-			waitForWorkers(remainder, results)
-		} else {
-			// Launch goroutines for each repo
-			for _, project := range repolist.([]interface{}) {
-				go analyseRepoFunc(project, DestinationResult, platformConfig, spin, results, &count)
-			}
-			waitForWorkers(len(repolist.([]interface{})), results)
+	// Each job only writes its own attempts
+	attempts := make([]int, len(repolist))
+	files := newResultFiles(jr.Entries())
+
+	// Written with the results of each repository
+	metadata := report.ResultMetadata{
+		Platform:     platformConfig["DevOps"].(string),
+		Organization: platformConfig["Organization"].(string),
+		Version:      version,
+	}
+
+	jobs := make([]scheduler.Job, 0, len(repolist))
+	for i, params := range repolist {
+		i, params := i, params
+		jobs = append(jobs, scheduler.Job{
+			Name: params.RepoSlug,
+			Run: func(ctx context.Context) error {
+				var err error
+				attempts[i], err = policy.Retry.Do(ctx, func(ctx context.Context) error {
+					return performRepoAnalysis(ctx, params, DestinationResult, jr, files, metadata, policy.Timeout)
+				}, gogit.IsTransient)
+				return err
+			},
+		})
+	}
+
+	sched := scheduler.New(scanWorkers(platformConfig))
+	results := sched.Run(ctx, jobs, func(result scheduler.Result) {
+		switch result.Status {
+		case scheduler.Done:
+			fmt.Printf("\r✅ %d The repository <%s> has been analyzed\n", sched.Done(), result.Name)
+		case scheduler.Failed:
+			fmt.Printf("\r❌ The analysis of the repository <%s> failed: %v\n", result.Name, result.Err)
 		}
-	} else {
-		// Without multithreading
-		for _, project := range repolist.([]interface{}) {
-			// Execute the analysis synchronously
-			analyseRepoFunc(project, DestinationResult, platformConfig, spin, results, &count)
+	})
+
+	var failures []repoFailure
+	for i, result := range results {
+		if result.Status == scheduler.Failed {
+			failures = append(failures, repoFailure{
+				ProjectKey: repolist[i].ProjectKey,
+				Namespace:  repolist[i].Namespace,
+				RepoSlug:   repolist[i].RepoSlug,
+				Branch:     repolist[i].MainBranch,
+				Reason:     result.Err.Error(),
+				Attempts:   attempts[i],
+			})
+		}
+	}
+	if err := saveFailures(DestinationResult, failures); err != nil {
+		fmt.Println("❌ Error writing failures file:", err)
+	}
+
+	if ctx.Err() != nil {
+		fmt.Printf("\n❗️ Analysis interrupted - %d repositories not analyzed, run again with -resume to finish them\n", sched.Cancelled())
+	}
+	fmt.Printf("\n✅ Repositories analyzed: %d - Failed: %d - Cancelled: %d\n", sched.Done(), sched.Failed(), sched.Cancelled())
+
+	return sched.Done(), sched.Failed(), sched.Cancelled()
+}
+
+// Write the repositories whose analysis failed in <DestinationResult>/failures.json
+func saveFailures(DestinationResult string, failures []repoFailure) error {
+	filePath := filepath.Join(DestinationResult, failuresFile)
+	if len(failures) == 0 {
+		// Remove the failures of a previous run
+		if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+			return err
 		}
+		return nil
 	}
 
-	return len(repolist.([]interface{}))
+	data, err := json.MarshalIndent(failures, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filePath, data, 0644)
 }
 
-// Analysis functions for different repository types
+// Repository parameters for different repository types
 
-// Analysis functions for Bitbucket Cloud
-func analyseBitCRepo(project interface{}, DestinationResult string, platformConfig map[string]interface{}, spin *spinner.Spinner, results chan int, count *int) {
-	p := project.(getbibucket.ProjectBranch)
-	params := RepoParams{
+// Repository parameters for Bitbucket Cloud
+func bitCRepoParams(p getbibucket.ProjectBranch, platformConfig map[string]interface{}) RepoParams {
+	return RepoParams{
 		ProjectKey: p.ProjectKey,
 		Namespace:  "",
 		RepoSlug:   p.RepoSlug,
 		MainBranch: p.MainBranch,
 		PathToScan: fmt.Sprintf("%s://x-token-auth:%s@%s/%s/%s.git", platformConfig["Protocol"].(string), platformConfig["AccessToken"].(string), platformConfig["Baseapi"].(string), platformConfig["Workspace"].(string), p.RepoSlug),
 	}
-	performRepoAnalysis(params, DestinationResult, spin, results, count)
 }
 
-// Analysis functions for Bitbucket DC
-func analyseBitSRVRepo(project interface{}, DestinationResult string, platformConfig map[string]interface{}, trimmedURL string, spin *spinner.Spinner, results chan int, count *int) {
-	p := project.(getbibucketdc.ProjectBranch)
-	params := RepoParams{
+// Repository parameters for Bitbucket DC
+func bitSRVRepoParams(p getbibucketdc.ProjectBranch, platformConfig map[string]interface{}) RepoParams {
+	URLcut := platformConfig["Protocol"].(string) + "://"
+	trimmedURL := strings.TrimPrefix(platformConfig["Url"].(string), URLcut)
+	return RepoParams{
 		ProjectKey: p.ProjectKey,
 		Namespace:  "",
 		RepoSlug:   p.RepoSlug,
 		MainBranch: p.MainBranch,
 		PathToScan: fmt.Sprintf("%s://%s:%s@%sscm/%s/%s.git", platformConfig["Protocol"].(string), platformConfig["Users"].(string), platformConfig["AccessToken"].(string), trimmedURL, p.ProjectKey, p.RepoSlug),
 	}
-	performRepoAnalysis(params, DestinationResult, spin, results, count)
 }
 
-// Analysis functions for GitHub
-func analyseGithubRepo(project interface{}, DestinationResult string, platformConfig map[string]interface{}, spin *spinner.Spinner, results chan int, count *int) {
-	p := project.(getgithub.ProjectBranch)
-	params := RepoParams{
+// Repository parameters for GitHub
+func githubRepoParams(p getgithub.ProjectBranch, platformConfig map[string]interface{}) RepoParams {
+	return RepoParams{
 		ProjectKey: p.Org,
 		Namespace:  "",
 		RepoSlug:   p.RepoSlug,
 		MainBranch: p.MainBranch,
 		PathToScan: fmt.Sprintf("%s://%s:x-oauth-basic@%s/%s/%s.git", platformConfig["Protocol"].(string), platformConfig["AccessToken"].(string), platformConfig["Baseapi"].(string), p.Org, p.RepoSlug),
 	}
-	performRepoAnalysis(params, DestinationResult, spin, results, count)
 }
 
-// Analysis functions for GitLab
-func analyseGitlabRepo(project interface{}, DestinationResult string, platformConfig map[string]interface{}, spin *spinner.Spinner, results chan int, count *int) {
-	p := project.(getgitlab.ProjectBranch)
-	params := RepoParams{
+// Repository parameters for GitLab
+func gitlabRepoParams(p getgitlab.ProjectBranch, platformConfig map[string]interface{}) RepoParams {
+	return RepoParams{
 		ProjectKey: p.Org,
 		Namespace:  p.Namespace,
 		RepoSlug:   p.RepoSlug,
 		MainBranch: p.MainBranch,
 		PathToScan: fmt.Sprintf("%s://gitlab-ci-token:%s@%s/%s.git", platformConfig["Protocol"].(string), platformConfig["AccessToken"].(string), "gitlab.com", p.Namespace),
 	}
-	performRepoAnalysis(params, DestinationResult, spin, results, count)
 }
 
-func analyseAzurebRepo(project interface{}, DestinationResult string, platformConfig map[string]interface{}, spin *spinner.Spinner, results chan int, count *int) {
-	p := project.(getazure.ProjectBranch)
-	params := RepoParams{
+// Repository parameters for Azure DevOps
+func azureRepoParams(p getazure.ProjectBranch, platformConfig map[string]interface{}) RepoParams {
+	return RepoParams{
 		ProjectKey: p.ProjectKey,
 		Namespace:  "",
 		RepoSlug:   p.RepoSlug,
 		MainBranch: p.MainBranch,
 		PathToScan: fmt.Sprintf("%s://%s@%s/%s/%s/%s/%s", platformConfig["Protocol"].(string), platformConfig["AccessToken"].(string), "dev.azure.com", platformConfig["Organization"].(string), p.ProjectKey, "_git", p.RepoSlug),
 	}
-	performRepoAnalysis(params, DestinationResult, spin, results, count)
 }
 
-// Perform repository analysis (common logic)
-func performRepoAnalysis(params RepoParams, DestinationResult string, spin *spinner.Spinner, results chan int, count *int) {
-	var outputFileName = ""
+// Select the repositories and branches to analyse on a DevOps platform
+func selectRepos(platformConfig map[string]interface{}) ([]RepoParams, error) {
+	var repolist []RepoParams
+
+	switch devops := platformConfig["DevOps"].(string); devops {
+
+	case "azure":
+		fileexclusionEX := getFileNameIfExists(".cloc_azure_ignore")
+
+		gitproject, err := getazure.GetRepoAzureList(platformConfig, fileexclusionEX)
+		if err != nil {
+			return nil, fmt.Errorf(errorMessageRepos, platformConfig["Organization"].(string), err)
+		}
+		for _, p := range gitproject {
+			repolist = append(repolist, azureRepoParams(p, platformConfig))
+		}
+
+	case "github":
+		fileexclusionEX := getFileNameIfExists(".cloc_github_ignore")
+
+		repositories, err := getgithub.GetRepoGithubList(platformConfig, fileexclusionEX, false)
+		if err != nil {
+			return nil, fmt.Errorf(errorMessageRepos, platformConfig["Organization"].(string), err)
+		}
+		for _, p := range repositories {
+			repolist = append(repolist, githubRepoParams(p, platformConfig))
+		}
+
+	case "gitlab":
+		fileexclusionEX := getFileNameIfExists(".cloc_gitlab_ignore")
+
+		gitproject, err := getgitlab.GetRepoGitLabList(platformConfig, fileexclusionEX)
+		if err != nil {
+			return nil, fmt.Errorf(errorMessageRepos, platformConfig["Organization"].(string), err)
+		}
+		for _, p := range gitproject {
+			repolist = append(repolist, gitlabRepoParams(p, platformConfig))
+		}
+
+	case "bitbucket_dc":
+		fileexclusionEX := getFileNameIfExists(platformConfig["FileExclusion"].(string))
+
+		projects, err := getbibucketdc.GetProjectBitbucketList(platformConfig, fileexclusionEX)
+		if err != nil {
+			return nil, fmt.Errorf("Error Get Info Projects in Bitbucket server '%s'", err)
+		}
+		for _, p := range projects {
+			repolist = append(repolist, bitSRVRepoParams(p, platformConfig))
+		}
+
+	case "bitbucket":
+		fileexclusionEX := getFileNameIfExists(platformConfig["FileExclusion"].(string))
+
+		projects1, err := getbibucket.GetProjectBitbucketListCloud(platformConfig, fileexclusionEX)
+		if err != nil {
+			return nil, fmt.Errorf("Error Get Info Project(s) in Bitbucket cloud '%v'", err)
+		}
+		for _, p := range projects1 {
+			repolist = append(repolist, bitCRepoParams(p, platformConfig))
+		}
+
+	default:
+		return nil, fmt.Errorf("DevOps platform '%s' not supported", devops)
+	}
+
+	return repolist, nil
+}
+
+// Name of the json report of a repository, relative to the results directory.
+// The / of the groups and branches are flattened in the readable part of the name, so a short hash of the
+// repository and branch keeps apart the names of repositories such as grp/a_b at main and grp/a at b_main.
+func resultFileName(params RepoParams) string {
+	name := params.ProjectKey + "_" + params.RepoSlug
 	if len(params.Namespace) > 0 {
-		outputFileName = fmt.Sprintf("Result_%s_%s", params.Namespace, params.MainBranch)
-	} else {
-		outputFileName = fmt.Sprintf("Result_%s_%s_%s", params.ProjectKey, params.RepoSlug, params.MainBranch)
+		name = params.Namespace
+	}
+	sum := sha256.Sum256([]byte(strings.Join([]string{params.ProjectKey, params.Namespace, params.RepoSlug, params.MainBranch}, "\x00")))
+	return fmt.Sprintf("Result_%s_%s_%x", strings.ReplaceAll(name, "/", "_"), strings.ReplaceAll(params.MainBranch, "/", "_"), sum[:4])
+}
+
+// Key of the branch of a repository whose results are written in a result file
+func resultKey(projectKey, namespace, repoSlug, branch string) string {
+	return journal.Key(projectKey, namespace, repoSlug) + "@" + branch
+}
+
+// Result files of a run with the repository writing each of them,
+// so that a repository never overwrites the results of another one
+type resultFiles struct {
+	mu     sync.Mutex
+	owners map[string]string
+}
+
+// Result files of a run, with the ones of the previous run when it is resumed
+func newResultFiles(entries []journal.Entry) *resultFiles {
+	files := &resultFiles{owners: make(map[string]string)}
+	for _, entry := range entries {
+		files.owners[entry.ResultFile] = resultKey(entry.ProjectKey, entry.Namespace, entry.RepoSlug, entry.Branch)
+	}
+	return files
+}
+
+// Reserve a result file for a repository before its results are written
+func (f *resultFiles) claim(resultFile, key string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if owner, ok := f.owners[resultFile]; ok && owner != key {
+		return fmt.Errorf("the result file %s of %s is already used by %s", resultFile, key, owner)
+	}
+	f.owners[resultFile] = key
+	return nil
+}
+
+// Remove from the list the repositories already analysed at the current commit of their branch
+func pendingRepos(ctx context.Context, DestinationResult string, platformConfig map[string]interface{}, repolist []RepoParams, jr *journal.Journal, policy repoPolicy) []RepoParams {
+	var pending []RepoParams
+
+	fmt.Print("\n🔎 Checking the journal of the previous run ...\n")
+
+	// The commits are looked up by the workers of the analysis, with its timeout for each repository
+	token, _ := platformConfig["AccessToken"].(string)
+	commits := make([]string, len(repolist))
+	jobs := make([]scheduler.Job, 0, len(repolist))
+	for i, params := range repolist {
+		i, params := i, params
+		jobs = append(jobs, scheduler.Job{
+			Name: params.RepoSlug,
+			Run: func(ctx context.Context) error {
+				if policy.Timeout > 0 {
+					var cancel context.CancelFunc
+					ctx, cancel = context.WithTimeout(ctx, policy.Timeout)
+					defer cancel()
+				}
+				var err error
+				commits[i], err = gogit.RemoteCommitContext(ctx, params.PathToScan, params.MainBranch, token)
+				return err
+			},
+		})
+	}
+	results := scheduler.New(scanWorkers(platformConfig)).Run(ctx, jobs, nil)
+
+	for i, params := range repolist {
+		// A repository whose commit is unknown is analysed again
+		if results[i].Status != scheduler.Done {
+			pending = append(pending, params)
+			continue
+		}
+		commit := commits[i]
+
+		entry, done := jr.Done(journal.Key(params.ProjectKey, params.Namespace, params.RepoSlug), commit)
+		if done && entry.Branch == params.MainBranch {
+			if _, err := os.Stat(filepath.Join(DestinationResult, entry.ResultFile)); err == nil {
+				fmt.Printf("\t✅ Skipping <%s>, already analysed at commit %.8s\n", params.RepoSlug, commit)
+				continue
+			}
+		}
+		pending = append(pending, params)
+	}
+
+	fmt.Printf("✅ %d repositories already analysed - %d remaining\n", len(repolist)-len(pending), len(pending))
+
+	return pending
+}
+
+// Perform repository analysis (common logic), its results are written with metadata
+func performRepoAnalysis(ctx context.Context, params RepoParams, DestinationResult string, jr *journal.Journal, files *resultFiles, metadata report.ResultMetadata, timeout time.Duration) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	outputFileName := resultFileName(params)
+	key := resultKey(params.ProjectKey, params.Namespace, params.RepoSlug, params.MainBranch)
+	if err := files.claim(filepath.Base(goloc.ReportPath(DestinationResult, outputFileName, ".json")), key); err != nil {
+		return err
 	}
 	golocParams := goloc.Params{
 		Path:              params.PathToScan,
@@ -400,147 +697,179 @@ func performRepoAnalysis(params RepoParams, DestinationResult string, spin *spin
 		OutputPath:        DestinationResult,
 		ReportFormats:     []string{"json"},
 		Branch:            params.MainBranch,
+		// The output of the parallel clones would be mixed, the scheduler reports each repository
+		Quiet: true,
 	}
-	MessB := fmt.Sprintf("   Extracting files from repo : %s ", params.RepoSlug)
-	spin.Suffix = MessB
-	spin.Start()
+	fmt.Printf("\r   Extracting files from repo : %s\n", params.RepoSlug)
 
-	gc, err := goloc.NewGCloc(golocParams, assets.Languages)
+	gc, err := goloc.NewGClocContext(ctx, golocParams, assets.Languages)
 	if err != nil {
-		fmt.Println(errorMessageRepo, err)
-		return
+		return deadlineError(ctx, err)
 	}
 
-	gc.Run()
-	*count++
-
 	// Remove Repository Directory
-	err1 := os.RemoveAll(gc.Repopath)
-	if err1 != nil {
-		fmt.Printf(errorMessageDi, err1)
+	defer func() {
+		if err := os.RemoveAll(gc.Repopath); err != nil {
+			fmt.Printf(errorMessageDi, err)
+		}
+	}()
+
+	// The timeout also covers the scan of the cloned files
+	if _, err := gc.RunContext(ctx); err != nil {
+		return deadlineError(ctx, err)
 	}
 
-	spin.Stop()
-	fmt.Printf("\r✅ %d The repository <%s> has been analyzed\n", *count, params.RepoSlug)
+	commit, _ := gogit.HeadCommit(gc.Repopath)
+	metadata.Project = params.ProjectKey
+	metadata.Namespace = params.Namespace
+	metadata.Repository = params.RepoSlug
+	metadata.Branch = params.MainBranch
+	metadata.Commit = commit
+	metadata.ScanTime = time.Now().UTC()
 
-	// Send result through channel
-	results <- 1
+	resultFile := gc.ReportPath(".json")
+	if err := report.WriteResultMetadata(resultFile, metadata); err != nil {
+		return err
+	}
+
+	err = jr.Record(metadata.Entry(filepath.Base(resultFile)))
+	if err != nil {
+		fmt.Println("❌ Error writing journal file:", err)
+	}
+
+	return nil
 }
 
-// Wait for all goroutines to complete
-func waitForWorkers(numWorkers int, results chan int) {
-	for i := 0; i < numWorkers; i++ {
-		fmt.Printf("\r Waiting for workers...\n")
-		<-results
+// Return the error of a repository with the error of its context when the context is done,
+// so that a repository whose deadline is exceeded is not retried whatever the clone or scan error
+func deadlineError(ctx context.Context, err error) error {
+	if ctx.Err() != nil && !errors.Is(err, ctx.Err()) {
+		return fmt.Errorf("%w: %v", ctx.Err(), err)
 	}
+	return err
 }
 
-// Specific analysis functions calling the generic one
+/* ---------------- Analyse Directory ---------------- */
+
+// Analyse the directories of the File platform like the repositories, with the workers of the configuration
+func AnalyseReposListFile(ctx context.Context, DestinationResult string, platformConfig map[string]interface{}, Listdirectorie, fileexclusionEX []string, jr *journal.Journal, policy repoPolicy) (analysed, failed, cancelled int) {
+
+	fmt.Print("\n🔎 Analysis of Directories ...\n")
+
+	// Each job only writes its own attempts
+	attempts := make([]int, len(Listdirectorie))
+	files := newResultFiles(jr.Entries())
 
-// Analysis function call for BitBucket Cloud
-func AnalyseReposListBitC(DestinationResult string, platformConfig map[string]interface{}, repolist []getbibucket.ProjectBranch) (cpt int) {
-	repoInterfaces := make([]interface{}, len(repolist))
-	for i, v := range repolist {
-		repoInterfaces[i] = v
+	// Written with the results of each directory
+	metadata := report.ResultMetadata{
+		Platform:     platformConfig["DevOps"].(string),
+		Organization: platformConfig["Organization"].(string),
+		Version:      version,
 	}
-	return AnalyseReposList(DestinationResult, platformConfig, repoInterfaces, analyseBitCRepo)
-}
 
-// Analysis function call for BitBucket DC
-func AnalyseReposListBitSRV(DestinationResult string, platformConfig map[string]interface{}, repolist []getbibucketdc.ProjectBranch) (cpt int) {
-	URLcut := platformConfig["Protocol"].(string) + "://"
-	trimmedURL := strings.TrimPrefix(platformConfig["Url"].(string), URLcut)
-	repoInterfaces := make([]interface{}, len(repolist))
-	for i, v := range repolist {
-		repoInterfaces[i] = v
+	jobs := make([]scheduler.Job, 0, len(Listdirectorie))
+	for i, dir := range Listdirectorie {
+		i, dir := i, dir
+		jobs = append(jobs, scheduler.Job{
+			Name: dir,
+			Run: func(ctx context.Context) error {
+				var err error
+				attempts[i], err = policy.Retry.Do(ctx, func(ctx context.Context) error {
+					return performDirAnalysis(ctx, dir, fileexclusionEX, DestinationResult, jr, files, metadata, policy.Timeout)
+				}, gogit.IsTransient)
+				return err
+			},
+		})
 	}
-	return AnalyseReposList(DestinationResult, platformConfig, repoInterfaces, func(project interface{}, DestinationResult string, platformConfig map[string]interface{}, spin *spinner.Spinner, results chan int, count *int) {
-		analyseBitSRVRepo(project, DestinationResult, platformConfig, trimmedURL, spin, results, count)
+
+	sched := scheduler.New(scanWorkers(platformConfig))
+	results := sched.Run(ctx, jobs, func(result scheduler.Result) {
+		switch result.Status {
+		case scheduler.Done:
+			fmt.Printf("\r\t✅ %d The directory <%s> has been analyzed\n", sched.Done(), result.Name)
+		case scheduler.Failed:
+			fmt.Printf("\r❌ The analysis of the directory <%s> failed: %v\n", result.Name, result.Err)
+		}
 	})
-}
 
-// Analysis function call for GitHub
-func AnalyseReposListGithub(DestinationResult string, platformConfig map[string]interface{}, repolist []getgithub.ProjectBranch) (cpt int) {
-	repoInterfaces := make([]interface{}, len(repolist))
-	for i, v := range repolist {
-		repoInterfaces[i] = v
+	var failures []repoFailure
+	for i, result := range results {
+		if result.Status == scheduler.Failed {
+			failures = append(failures, repoFailure{
+				RepoSlug: Listdirectorie[i],
+				Reason:   result.Err.Error(),
+				Attempts: attempts[i],
+			})
+		}
 	}
-	return AnalyseReposList(DestinationResult, platformConfig, repoInterfaces, analyseGithubRepo)
-}
-
-// Analysis function call for Gitlab
-func AnalyseReposListGitlab(DestinationResult string, platformConfig map[string]interface{}, repolist []getgitlab.ProjectBranch) (cpt int) {
-	repoInterfaces := make([]interface{}, len(repolist))
-	for i, v := range repolist {
-		repoInterfaces[i] = v
+	if err := saveFailures(DestinationResult, failures); err != nil {
+		fmt.Println("❌ Error writing failures file:", err)
 	}
-	return AnalyseReposList(DestinationResult, platformConfig, repoInterfaces, analyseGitlabRepo)
-}
 
-// Analysis function call for Gitlab
-func AnalyseReposListAzure(DestinationResult string, platformConfig map[string]interface{}, repolist []getazure.ProjectBranch) (cpt int) {
-	repoInterfaces := make([]interface{}, len(repolist))
-	for i, v := range repolist {
-		repoInterfaces[i] = v
+	if ctx.Err() != nil {
+		fmt.Printf("\n❗️ Analysis interrupted - %d directories not analyzed\n", sched.Cancelled())
 	}
-	return AnalyseReposList(DestinationResult, platformConfig, repoInterfaces, analyseAzurebRepo)
+	fmt.Printf("\n✅ Directories analyzed: %d - Failed: %d - Cancelled: %d\n", sched.Done(), sched.Failed(), sched.Cancelled())
+
+	return sched.Done(), sched.Failed(), sched.Cancelled()
 }
 
-/* ---------------- Analyse Directory ---------------- */
+// Analyse a directory in place, its results are written with metadata and recorded in the journal
+func performDirAnalysis(ctx context.Context, dir string, fileexclusionEX []string, DestinationResult string, jr *journal.Journal, files *resultFiles, metadata report.ResultMetadata, timeout time.Duration) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
 
-func AnalyseReposListFile(Listdirectorie, fileexclusionEX []string) {
+	params := goloc.Params{
+		Path:              dir,
+		ByFile:            false,
+		ExcludePaths:      fileexclusionEX,
+		ExcludeExtensions: []string{},
+		IncludeExtensions: []string{},
+		OrderByLang:       false,
+		OrderByFile:       false,
+		OrderByCode:       false,
+		OrderByLine:       false,
+		OrderByBlank:      false,
+		OrderByComment:    false,
+		Order:             "DESC",
+		OutputName:        "Result_",
+		OutputPath:        DestinationResult,
+		ReportFormats:     []string{"json"},
+		Branch:            "",
+		Token:             "",
+		// The output of the parallel scans would be mixed, the scheduler reports each directory
+		Quiet: true,
+	}
 
-	fmt.Print("\n🔎 Analysis of Directories ...\n")
+	gc, err := goloc.NewGClocContext(ctx, params, assets.Languages)
+	if err != nil {
+		return deadlineError(ctx, err)
+	}
+	// Two directories with the same name would write the same result file
+	if err := files.claim(filepath.Base(gc.ReportPath(".json")), gc.Repopath); err != nil {
+		return err
+	}
+	if _, err := gc.RunContext(ctx); err != nil {
+		return deadlineError(ctx, err)
+	}
 
-	var wg sync.WaitGroup
-	wg.Add(len(Listdirectorie))
-
-	for _, Listdirectories := range Listdirectorie {
-		go func(dir string) {
-			defer wg.Done()
-
-			spin := spinner.New(spinner.CharSets[35], 100*time.Millisecond)
-			spin.Color("green", "bold")
-			messageF := ""
-			spin.FinalMSG = messageF
-
-			outputFileName := "Result_"
-			count := 1
-
-			params := goloc.Params{
-				Path:              dir,
-				ByFile:            false,
-				ExcludePaths:      fileexclusionEX,
-				ExcludeExtensions: []string{},
-				IncludeExtensions: []string{},
-				OrderByLang:       false,
-				OrderByFile:       false,
-				OrderByCode:       false,
-				OrderByLine:       false,
-				OrderByBlank:      false,
-				OrderByComment:    false,
-				Order:             "DESC",
-				OutputName:        outputFileName,
-				OutputPath:        "Results",
-				ReportFormats:     []string{"json"},
-				Branch:            "",
-				Token:             "",
-			}
+	metadata.Repository = filepath.Base(gc.Repopath)
+	metadata.Commit, _ = gogit.HeadCommit(gc.Repopath)
+	metadata.ScanTime = time.Now().UTC()
 
-			gc, err := goloc.NewGCloc(params, assets.Languages)
-			if err != nil {
-				fmt.Println(errorMessageRepo, err)
-				return
-			}
+	resultFile := gc.ReportPath(".json")
+	if err := report.WriteResultMetadata(resultFile, metadata); err != nil {
+		return err
+	}
 
-			gc.Run()
-			spin.Stop()
-			fmt.Printf("\r\t✅ %d The directory <%s> has been analyzed\n", count, dir)
-			count++
-		}(Listdirectories)
+	if err := jr.Record(metadata.Entry(filepath.Base(resultFile))); err != nil {
+		fmt.Println("❌ Error writing journal file:", err)
 	}
 
-	wg.Wait()
+	return nil
 }
 
 /* ---------------- End Analyse Directory ---------------- */
@@ -641,137 +970,135 @@ func displayLanguages() {
 	}
 }
 
-func main() {
+// Load the configuration of a DevOps platform from the config file
+func loadPlatformConfig(configFile, target string) (map[string]interface{}, error) {
+	AppConfig, err := LoadConfig(configFile)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to load config: %s", err)
+	}
 
-	var maxTotalCodeLines int
-	var maxProject, maxRepo string
-	var NumberRepos int
-	var startTime time.Time
-	var ListDirectory []string
-	var ListExclusion []string
-	var message3, message4, message5 string
-	var version = "1.0.3"
+	platformConfig, ok := AppConfig.Platforms[target].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("Configuration for DevOps platform '%s' not found, the target is : %s", target, platformTargets)
+	}
 
-	// Test command line Flags
+	return platformConfig, nil
+}
 
-	devopsFlag := flag.String("devops", "", "Specify the DevOps platform")
-	fastFlag := flag.Bool("fast", false, "Enable fast mode (only for Github)")
-	helpFlag := flag.Bool("help", false, "Show help message")
-	languagesFlag := flag.Bool("languages", false, "Show all supported languages")
-	versionflag := flag.Bool("version", false, "Show version")
-	docker := flag.Bool("docker", false, "Run in Docker mode")
+// Load the directories to analyse and the exclusions of the File platform
+func loadFileDirectories(platformConfig map[string]interface{}) ([]string, []string, error) {
+	var ListDirectory []string
+	var ListExclusion []string
+	var err error
 
-	flag.Parse()
+	fileexclusionEX := getFileNameIfExists(platformConfig["FileExclusion"].(string))
+	fileload := getFileNameIfExists(platformConfig["FileLoad"].(string))
 
-	if *helpFlag {
-		fmt.Println("Usage: golc -devops [OPTIONS]")
-		fmt.Println("Options:  <BitBucketSRV>||<BitBucket>||<Github>||<Gitlab>||<Azure>||<File>")
-		flag.PrintDefaults()
-		os.Exit(0)
+	if fileexclusionEX != "0" {
+		ListExclusion, err = ReadLines(fileexclusionEX)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Error reading file <.cloc_file_ignore>: %v", err)
+		}
+	} else {
+		ListExclusion = make([]string, 0)
 	}
 
-	if *languagesFlag {
-		displayLanguages()
-		os.Exit(0)
+	if fileload != "0" {
+		ListDirectory, err = ReadLines(fileload)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Error reading file <.cloc_file_file>: %v", err)
+		}
+		if len(ListDirectory) == 0 {
+			ListDirectory = append(ListDirectory, platformConfig["Directory"].(string))
+		}
+	} else {
+		if len(platformConfig["Directory"].(string)) == 0 {
+			return nil, nil, fmt.Errorf("No analysis possible, no directory, specified file or specified loading file")
+		}
+		ListDirectory = append(ListDirectory, platformConfig["Directory"].(string))
 	}
 
-	if *versionflag {
-		fmt.Printf("GoLC version: %s %s/%s\n", version, runtime.GOOS, runtime.GOARCH)
-		os.Exit(0)
-	}
+	return ListDirectory, ListExclusion, nil
+}
 
-	if *devopsFlag == "" {
-		fmt.Println("\n❌ Please specify the DevOps platform using the -devops flag : <BitBucketSRV>||<BitBucket>||<Github>||<Gitlab>||<Azure>||<File>")
-		fmt.Println("✅ Example for BitBucket server : golc -devops BitBucketSRV")
+// Options of the scan command
+type scanOptions struct {
+	Target     string
+	ConfigFile string
+	Output     string
+	OnExisting string
+	Yes        bool
+	Fast       bool
+	Docker     bool
+	Resume     bool
+	Timeout    time.Duration
+	Retries    int
+	SQLite     string
+}
+
+// Run the analysis of a DevOps platform defined in the config file
+func runScan(opts scanOptions) {
+
+	var maxTotalCodeLines int
+	var maxProject, maxRepo string
+	var NumberRepos, failedRepos, cancelledRepos int
+	var startTime time.Time
+	var message3, message4, message5 string
+
+	platformConfig, err := loadPlatformConfig(opts.ConfigFile, opts.Target)
+	if err != nil {
+		fmt.Printf("\n❌ %s\n", err)
 		os.Exit(1)
 	}
 
-	AppConfig, err := LoadConfig("config.json")
+	fmt.Printf("\n✅ Using configuration for DevOps platform '%s'\n", opts.Target)
+
+	// Test whether to delete the Results directory and save it before deleting.
+
+	DestinationResult, err := filepath.Abs(opts.Output)
 	if err != nil {
-		log.Fatalf("\n❌ Failed to load config: %s", err)
+		fmt.Println("❌ Error resolving output directory:", err)
 		os.Exit(1)
 	}
 
-	platformConfig, ok := AppConfig.Platforms[*devopsFlag].(map[string]interface{})
-	if !ok {
-		fmt.Printf("\n❌ Configuration for DevOps platform '%s' not found\n", *devopsFlag)
-		fmt.Println("✅ the -devops flag is : <BitBucketSRV>||<BitBucket>||<Github>||<Gitlab>||<Azure>||<File>")
+	onExisting := opts.OnExisting
+	switch onExisting {
+	case "", onExistingBackup, onExistingOverwrite, onExistingFail, onExistingTimestamp:
+	default:
+		fmt.Printf("\n❌ Invalid -on-existing value '%s' : <backup>||<overwrite>||<fail>||<timestamp>\n", onExisting)
 		os.Exit(1)
 	}
 
-	fmt.Printf("\n✅ Using configuration for DevOps platform '%s'\n", *devopsFlag)
-
-	// Test whether to delete the Results directory and save it before deleting.
-
-	pwd, err := os.Getwd()
-	if err != nil {
-		fmt.Println("Error:", err)
+	if opts.Resume {
+		// The results of the interrupted run are kept
+		if onExisting != "" {
+			fmt.Println("\n❌ -resume can not be used with -on-existing")
+			os.Exit(1)
+		}
+		onExisting = onExistingKeep
 	}
-	DestinationResult := pwd + "/Results"
 
-	if *docker {
+	if opts.Docker {
 		fmt.Println("Running in Docker mode")
-		ConfigDirectory := DestinationResult + directoryconf
-		if err := os.MkdirAll(ConfigDirectory, os.ModePerm); err != nil {
-			panic(err)
+		if onExisting == "" {
+			onExisting = onExistingKeep
 		}
+	} else if onExisting == "" && opts.Yes {
+		onExisting = onExistingBackup
+	}
 
-	} else {
-
-		_, err = os.Stat(DestinationResult)
-		if err == nil {
-
-			fmt.Printf("❗️ Directory <'%s'> already exists. Do you want to delete it? (y/n): ", DestinationResult)
-			var response string
-			fmt.Scanln(&response)
-
-			if response == "y" || response == "Y" {
-
-				fmt.Printf("❗️ Do you want to create a backup of the directory before deleting? (y/n): ")
-				var backupResponse string
-				fmt.Scanln(&backupResponse)
-
-				if backupResponse == "y" || backupResponse == "Y" {
-					// Créer la sauvegarde ZIP
-					err := createBackup(DestinationResult, pwd)
-					if err != nil {
-						fmt.Printf("❌ Error creating backup: %s\n", err)
-						os.Exit(1)
-					}
-				}
-
-				err := os.RemoveAll(DestinationResult)
-				if err != nil {
-					fmt.Printf("❌ Error deleting directory: %s\n", err)
-					os.Exit(1)
-				}
-				if err := os.MkdirAll(DestinationResult, os.ModePerm); err != nil {
-					panic(err)
-				}
-				ConfigDirectory := DestinationResult + directoryconf
-				if err := os.MkdirAll(ConfigDirectory, os.ModePerm); err != nil {
-					panic(err)
-				}
-			} else {
-				os.Exit(1)
-			}
-
-		} else if os.IsNotExist(err) {
-			if err := os.MkdirAll(DestinationResult, os.ModePerm); err != nil {
-				panic(err)
-			}
-			ConfigDirectory := DestinationResult + directoryconf
-			if err := os.MkdirAll(ConfigDirectory, os.ModePerm); err != nil {
-				panic(err)
-			}
-
-		}
+	DestinationResult, err = prepareResultsDir(DestinationResult, onExisting)
+	if err != nil {
+		fmt.Printf("❌ %s\n", err)
+		os.Exit(1)
 	}
+	platformConfig["ResultsDir"] = DestinationResult
+	platformConfig["Version"] = version
 	fmt.Printf("\n")
 
 	// Create Global Report File
 
-	GlobalReport := DestinationResult + "/GlobalReport.txt"
+	GlobalReport := filepath.Join(DestinationResult, "GlobalReport.txt")
 	file, err := os.Create(GlobalReport)
 	if err != nil {
 		fmt.Println("❌ Error creating file:", err)
@@ -781,179 +1108,81 @@ func main() {
 
 	// Open Logs
 
-	err = OpenLogFile("Results/Logs.log")
+	err = OpenLogFile(filepath.Join(DestinationResult, "Logs.log"))
 	if err != nil {
 		fmt.Println("❌ Error opening log file:", err)
 		return
 	}
 	defer CloseLogFile()
 
-	// Select DevOps Platform
-
-	switch devops := platformConfig["DevOps"].(string); devops {
-
-	case "azure":
-		var fileexclusion = ".cloc_azure_ignore"
-		fileexclusionEX := getFileNameIfExists(fileexclusion)
-
-		startTime = time.Now()
-
-		gitproject, err := getazure.GetRepoAzureList(platformConfig, fileexclusionEX)
-		if err != nil {
-			fmt.Printf(errorMessageRepos, platformConfig["Organization"].(string), err)
-
-			return
-		}
-
-		if len(gitproject) == 0 {
-			fmt.Printf(errorMessageAnalyse)
-			os.Exit(1)
-
-		} else {
-
-			NumberRepos = AnalyseReposListAzure(DestinationResult, platformConfig, gitproject)
-
-		}
-
-	case "github":
-
-		var fileexclusion = ".cloc_github_ignore"
-		fileexclusionEX := getFileNameIfExists(fileexclusion)
-		var fast bool
-
-		startTime = time.Now()
-
-		if *fastFlag {
-			fmt.Println("🚀 Fast mode enabled for Github")
-			fast = true
-			err := getgithub.FastAnalys(platformConfig, fileexclusionEX)
-
-			if err != nil {
-				fmt.Printf("❌ Quick scan Analysis : '%s'", err)
-				os.Exit(0)
-			}
-		} else {
-			fast = false
-
-			repositories, err := getgithub.GetRepoGithubList(platformConfig, fileexclusionEX, fast)
-			if err != nil {
-				fmt.Printf(errorMessageRepos, platformConfig["Organization"].(string), err)
-				return
-			}
-
-			if len(repositories) == 0 {
-				fmt.Printf(errorMessageAnalyse)
-				os.Exit(1)
-
-			} else {
+	// Open the journal of the analysed repositories
+	jr, err := journal.Open(filepath.Join(DestinationResult, "config", journalFile), opts.Resume)
+	if err != nil {
+		fmt.Println("❌ Error opening journal file:", err)
+		os.Exit(1)
+	}
+	defer jr.Close()
+
+	// Retry the transient failures of the DevOps platform APIs
+	retryPolicy := retry.Policy{Retries: opts.Retries, Backoff: 2 * time.Second}
+	http.DefaultTransport = retry.NewTransport(http.DefaultTransport, retryPolicy)
+
+	// Record the repositories skipped by the connectors for the run summary
+	inv := inventory.NewLog()
+	platformConfig[inventory.ConfigKey] = inv
+
+	// Stop the analysis on Ctrl-C, the repositories not analysed are cancelled
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	policy := repoPolicy{
+		Timeout: opts.Timeout,
+		Retry:   retryPolicy,
+	}
 
-				NumberRepos = AnalyseReposListGithub(DestinationResult, platformConfig, repositories)
+	// Select DevOps Platform
 
-			}
-		}
+	startTime = time.Now()
 
-	case "gitlab":
+	switch devops := platformConfig["DevOps"].(string); {
 
-		var fileexclusion = ".cloc_gitlab_ignore"
-		fileexclusionEX := getFileNameIfExists(fileexclusion)
+	case devops == "github" && opts.Fast:
+		fileexclusionEX := getFileNameIfExists(".cloc_github_ignore")
 
-		startTime = time.Now()
+		fmt.Println("🚀 Fast mode enabled for Github")
+		err := getgithub.FastAnalys(platformConfig, fileexclusionEX)
 
-		gitproject, err := getgitlab.GetRepoGitLabList(platformConfig, fileexclusionEX)
 		if err != nil {
-			fmt.Printf(errorMessageRepos, platformConfig["Organization"].(string), err)
-			return
+			fmt.Printf("❌ Quick scan Analysis : '%s'", err)
+			os.Exit(0)
 		}
 
-		if len(gitproject) == 0 {
-			fmt.Printf(errorMessageAnalyse)
+	case devops == "file":
+		ListDirectory, ListExclusion, err := loadFileDirectories(platformConfig)
+		if err != nil {
+			fmt.Printf("❌ %s\n", err)
 			os.Exit(1)
-
-		} else {
-			//os.Exit(1)
-			NumberRepos = AnalyseReposListGitlab(DestinationResult, platformConfig, gitproject)
-
 		}
+		addSelectedDirs(inv, ListDirectory)
+		NumberRepos, failedRepos, cancelledRepos = AnalyseReposListFile(ctx, DestinationResult, platformConfig, ListDirectory, ListExclusion, jr, policy)
 
-	case "bitbucket_dc":
-
-		var fileexclusion = platformConfig["FileExclusion"].(string)
-		fileexclusionEX := getFileNameIfExists(fileexclusion)
-
-		startTime = time.Now()
-		projects, err := getbibucketdc.GetProjectBitbucketList(platformConfig, fileexclusionEX)
+	default:
+		repolist, err := selectRepos(platformConfig)
 		if err != nil {
-			fmt.Printf("❌ Error Get Info Projects in Bitbucket server '%s' : ", err)
+			fmt.Printf("❌ %s\n", err)
 			os.Exit(1)
 		}
 
-		if len(projects) == 0 {
+		if len(repolist) == 0 {
 			fmt.Printf(errorMessageAnalyse)
 			os.Exit(1)
-
-		} else {
-
-			// Run scanning repositories
-			NumberRepos = AnalyseReposListBitSRV(DestinationResult, platformConfig, projects)
 		}
+		addSelectedRepos(inv, platformConfig, repolist)
 
-	case "bitbucket":
-		var fileexclusion = platformConfig["FileExclusion"].(string)
-		fileexclusionEX := getFileNameIfExists(fileexclusion)
-
-		startTime = time.Now()
-
-		projects1, err := getbibucket.GetProjectBitbucketListCloud(platformConfig, fileexclusionEX)
-
-		if err != nil {
-			fmt.Printf("❌ Error Get Info Project(s) in Bitbucket cloud '%v' ", err)
-			return
-		}
-		if len(projects1) == 0 {
-			fmt.Printf(errorMessageAnalyse)
-			os.Exit(1)
-
-		} else {
-
-			// Run scanning repositories
-			NumberRepos = AnalyseReposListBitC(DestinationResult, platformConfig, projects1)
+		if opts.Resume {
+			repolist = pendingRepos(ctx, DestinationResult, platformConfig, repolist, jr, policy)
 		}
 
-	case "file":
-
-		fileexclusionEX := getFileNameIfExists(platformConfig["FileExclusion"].(string))
-		fileload := getFileNameIfExists(platformConfig["FileLoad"].(string))
-
-		if fileexclusionEX != "0" {
-			ListExclusion, err = ReadLines(fileexclusionEX)
-			if err != nil {
-				fmt.Println("❌ Error reading file <.cloc_file_ignore>:", err)
-				os.Exit(1)
-			}
-		} else {
-			ListExclusion = make([]string, 0)
-
-		}
-
-		if fileload != "0" {
-			ListDirectory, err = ReadLines(fileload)
-			if err != nil {
-				fmt.Println("❌ Error reading file <.cloc_file_file>:", err)
-				os.Exit(1)
-			}
-			if len(ListDirectory) == 0 {
-				ListDirectory = append(ListDirectory, platformConfig["Directory"].(string))
-			}
-		} else {
-			if len(platformConfig["Directory"].(string)) == 0 {
-				fmt.Println("❌ No analysis possible, no directory, specified file or specified loading file")
-				os.Exit(1)
-			} else {
-				ListDirectory = append(ListDirectory, platformConfig["Directory"].(string))
-			}
-		}
-		startTime = time.Now()
-		AnalyseReposListFile(ListDirectory, ListExclusion)
+		NumberRepos, failedRepos, cancelledRepos = AnalyseReposList(ctx, DestinationResult, platformConfig, repolist, jr, policy)
 	}
 
 	// Begin of report file analysis
@@ -963,54 +1192,26 @@ func main() {
 	spin.Color("green", "bold")
 	spin.Start()
 
-	// List files in the directory
-	files, err := os.ReadDir(DestinationResult)
-	if err != nil {
-		fmt.Println("❌ Error listing files:", err)
-		os.Exit(1)
-	}
-
 	// Initialize the sum of TotalCodeLines
 	totalCodeLinesSum := 0
 
-	// Analyse All file
-	for _, file := range files {
-		// Check if the file is a JSON file
-		if !file.IsDir() && strings.HasSuffix(file.Name(), ".json") {
-			// Read contents of JSON file
-			filePath := filepath.Join(DestinationResult, file.Name())
-			jsonData, err := os.ReadFile(filePath)
-			if err != nil {
-				fmt.Printf("\n❌ Error reading file %s: %v\n", file.Name(), err)
-				continue
-			}
-
-			// Parse JSON content into a Result structure
-			var result Result
-			err = json.Unmarshal(jsonData, &result)
-			if err != nil {
-				fmt.Printf("\n❌ Error parsing JSON contents of file %s: %v\n", file.Name(), err)
-				continue
-			}
-
-			totalCodeLinesSum += result.TotalCodeLines
-
-			// Check if this repo has a higher TotalCodeLines than the current maximum
-			if result.TotalCodeLines > maxTotalCodeLines {
-				maxTotalCodeLines = result.TotalCodeLines
-				// Extract project and repo name from file name
-				parts := strings.Split(strings.TrimSuffix(file.Name(), ".json"), "_")
-				if platformConfig["DevOps"].(string) != "file" {
-					maxProject = parts[1]
-					maxRepo = parts[2]
-				} else {
-					maxProject = ""
-					maxRepo = parts[1]
-					NumberRepos++
-				}
-			}
+	// The repositories are read from the journal, including the ones of a resumed run,
+	// or from the metadata of their results without journal
+	repos, err := report.LoadRepos(DestinationResult)
+	if err != nil {
+		fmt.Println("\n❌ Error reading the results:", err)
+		os.Exit(1)
+	}
+	NumberRepos = len(repos)
+	for _, repo := range repos {
+		totalCodeLinesSum += repo.TotalCodeLines
+
+		// Check if this repo has a higher TotalCodeLines than the current maximum
+		if repo.TotalCodeLines > maxTotalCodeLines {
+			maxTotalCodeLines = repo.TotalCodeLines
+			maxProject = repo.Project
+			maxRepo = repo.Repository
 		}
-
 	}
 	maxTotalCodeLines1 := utils.FormatCodeLines(float64(maxTotalCodeLines))
 	totalCodeLinesSum1 := utils.FormatCodeLines(float64(totalCodeLinesSum))
@@ -1023,6 +1224,7 @@ func main() {
 		LinesOfCodeLargestRepo: maxTotalCodeLines1,
 		DevOpsPlatform:         platformConfig["DevOps"].(string),
 		NumberRepos:            NumberRepos,
+		Projects:               report.Projects(repos),
 	}
 
 	jsonData, err := json.MarshalIndent(data, "", "    ")
@@ -1031,12 +1233,12 @@ func main() {
 		return
 	}
 	// Created Global Result json file
-	file1, err := os.Create("Results/GlobalReport.json")
+	file1, err := os.Create(filepath.Join(DestinationResult, "GlobalReport.json"))
 	if err != nil {
 		fmt.Println("\n❌ Error during file creation Gobal Report:", err)
 		return
 	}
-	defer file.Close()
+	defer file1.Close()
 
 	_, err = file1.Write(jsonData)
 	if err != nil {
@@ -1049,6 +1251,23 @@ func main() {
 	endTime := time.Now()
 	duration := endTime.Sub(startTime)
 
+	err = report.SaveRunInfo(DestinationResult, report.RunInfo{
+		Start:    startTime,
+		Duration: duration.Seconds(),
+		Analysed: NumberRepos,
+		Failed:   failedRepos,
+		Statuses: inv.Counts(),
+		Version:  version,
+	})
+	if err != nil {
+		fmt.Println("\n❌ Error writing run summary:", err)
+	}
+
+	// The run is identified by its start in the database
+	if opts.SQLite != "" {
+		saveRun(opts.SQLite, DestinationResult)
+	}
+
 	hours := int(duration.Hours())
 	minutes := int(duration.Minutes()) % 60
 	seconds := int(duration.Seconds()) % 60
@@ -1058,7 +1277,13 @@ func main() {
 		message1 := fmt.Sprintf("✅ The repository with the largest line of code is in project <%s> the repo name is <%s> with <%s> lines of code\n", maxProject, maxRepo, maxTotalCodeLines1)
 		message2 := fmt.Sprintf("✅ The total sum of lines of code in Organization <%s> is : %s Lines of Code\n", platformConfig["Organization"].(string), totalCodeLinesSum1)
 		message4 = fmt.Sprintf("\n✅ Time elapsed : %02d:%02d:%02d\n", hours, minutes, seconds)
-		message3 = message0 + message1 + message2
+		message3 = message0 + message1 + message2 + projectsMessage(data.Projects)
+		if failedRepos > 0 {
+			message3 += fmt.Sprintf("❗️ The analysis of %d repositories failed, they are listed in %s\n", failedRepos, filepath.Join(DestinationResult, failuresFile))
+		}
+		if cancelledRepos > 0 {
+			message3 += fmt.Sprintf("❗️ The analysis was interrupted before %d repositories, run again with -resume to finish them\n", cancelledRepos)
+		}
 		message5 = message3 + message4
 
 	} else {
@@ -1071,7 +1296,7 @@ func main() {
 	}
 
 	fmt.Println(message3)
-	fmt.Println("\n✅ Reports are located in the <'Results'> directory")
+	fmt.Printf("\n✅ Reports are located in the <'%s'> directory\n", DestinationResult)
 	fmt.Println(message4)
 
 	// Write message in Gobal Report File
@@ -1082,6 +1307,229 @@ func main() {
 	}
 
 	fmt.Println("\nℹ️  To generate and visualize results on a web interface, follow these steps: ")
-	fmt.Println("\t✅ run : ResultsAll")
+	fmt.Printf("\t✅ run : golc report -results %s -serve\n", DestinationResult)
+
+	// The report is partial
+	if failedRepos > 0 || cancelledRepos > 0 {
+		os.Exit(2)
+	}
+}
+
+// Subtotals of the projects for the global report, only when there are several projects
+func projectsMessage(projects []report.ProjectSummary) string {
+	if len(projects) < 2 {
+		return ""
+	}
+
+	message := "\n✅ Lines of code by project :\n"
+	for _, project := range projects {
+		var languages []string
+		for i, lang := range project.Languages {
+			if i == 3 {
+				break
+			}
+			languages = append(languages, fmt.Sprintf("%s %.2f%%", lang.Language, lang.Percentage))
+		}
+		message += fmt.Sprintf("\t✅ Project <%s> : %d repositories with %s lines of code (%s), the largest is <%s> with %s lines of code\n",
+			project.Project, project.NumberRepos, project.TotalLinesOfCode, strings.Join(languages, ", "), project.LargestRepository, project.LinesOfCodeLargestRepo)
+	}
+	return message
+}
+
+// Command of the golc binary
+type command struct {
+	Name    string
+	Args    string
+	Summary string
+	Run     func(args []string)
+}
+
+func commands() []command {
+	return []command{
+		{"scan", "<target>", "Analyse the repositories of a DevOps platform", scanCommand},
+		{"count", "[paths...]", "Count the lines of code of local directories or remote sources, like cloc", countCommand},
+		{"list-repos", "<target>", "List the repositories and branches selected for analysis, without analysing them", listReposCommand},
+		{"inventory", "<target>", "Write the decision log of the repositories selected or skipped for analysis, without cloning them", inventoryCommand},
+		{"report", "", "Generate the PDF report of a results directory and optionally start its web visualization", reportCommand},
+		{"html", "", "Write the report of a results directory as a single HTML file, readable offline", htmlCommand},
+		{"markdown", "", "Write the markdown summary of a results directory, for pull requests and wikis", markdownCommand},
+		{"metrics", "", "Write the OpenMetrics file of a results directory, for the textfile collector of the node exporter", metricsCommand},
+		{"store", "", "Record the results directory of a run in a SQLite database", storeCommand},
+		{"serve", "", "Start the web visualization of a results directory, same as report -pdf=false -serve", serveCommand},
+		{"export", "", "Write one spreadsheet row per repository and language of a results directory", exportCommand},
+		{"diff", "<old> <new>", "Compare the lines of code of two results directories", diffCommand},
+		{"aggregate", "<results> <results>...", "Merge the results directories of several platforms and organizations in one global report", aggregateCommand},
+		{"history", "", "Show the growth of the code over the runs recorded in a SQLite database", historyCommand},
+		{"languages", "", "Show all supported languages", func(args []string) { displayLanguages() }},
+		{"version", "", "Show version", func(args []string) {
+			fmt.Printf("GoLC version: %s %s/%s\n", version, runtime.GOOS, runtime.GOARCH)
+		}},
+	}
+}
+
+func usage() {
+	fmt.Println("Usage: golc <command> [OPTIONS]")
+	fmt.Println("\nCommands:")
+	for _, cmd := range commands() {
+		fmt.Printf("  %-24s %s\n", strings.TrimSpace(cmd.Name+" "+cmd.Args), cmd.Summary)
+	}
+	fmt.Printf("\n<target> is a platform of the config file : %s\n", platformTargets)
+	fmt.Println("Run 'golc <command> -help' for the options of a command.")
+}
+
+// Create the flag set of a command with its usage message
+func newFlagSet(name, args, summary string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: golc %s [OPTIONS]\n\n%s\n\nOptions:\n", strings.TrimSpace(name+" "+args), summary)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// Flags shared by the commands
+func addConfigFlag(fs *flag.FlagSet) *string {
+	return fs.String("config", "config.json", "Path of the configuration file")
+}
+
+// Results directory of every command, -results is an alias of -output
+func addOutputFlag(fs *flag.FlagSet) *string {
+	output := fs.String("output", "Results", "Directory of the GoLC results")
+	fs.StringVar(output, "results", "Results", "Alias of -output")
+	return output
+}
+
+func addRetryFlags(fs *flag.FlagSet) (*time.Duration, *int) {
+	timeout := fs.Duration("timeout", 30*time.Minute, "Maximum time to clone and scan one repository, 0 for no limit")
+	retries := fs.Int("retries", 2, "Number of retries of a failed clone or API call")
+	return timeout, retries
+}
+
+// Parse the flags of a command, placed before or after its arguments, and return the arguments
+func parseArgs(fs *flag.FlagSet, args []string) []string {
+	var positional []string
+	for {
+		fs.Parse(args)
+		if fs.NArg() == 0 {
+			return positional
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
+
+// Check the number of arguments of a command
+func requireArgs(fs *flag.FlagSet, positional []string, n int) {
+	if len(positional) != n {
+		fs.Usage()
+		os.Exit(1)
+	}
+}
+
+func scanCommand(args []string) {
+	fs := newFlagSet("scan", "<target>", "Analyse the repositories of a DevOps platform")
+	configFlag := addConfigFlag(fs)
+	outputFlag := addOutputFlag(fs)
+	onExistingFlag := fs.String("on-existing", "", "What to do if the output directory exists: <backup>||<overwrite>||<fail>||<timestamp>")
+	yesFlag := fs.Bool("yes", false, "Do not prompt, assume yes (same as -on-existing backup when not set)")
+	fastFlag := fs.Bool("fast", false, "Enable fast mode (only for Github)")
+	docker := fs.Bool("docker", false, "Run in Docker mode")
+	resumeFlag := fs.Bool("resume", false, "Resume an interrupted analysis of the output directory, skipping the repositories already analysed at the same commit")
+	timeoutFlag, retriesFlag := addRetryFlags(fs)
+	sqliteFlag := fs.String("sqlite", "", "Also record the results of the run in this SQLite database")
+
+	positional := parseArgs(fs, args)
+	requireArgs(fs, positional, 1)
+
+	runScan(scanOptions{
+		Target:     positional[0],
+		ConfigFile: *configFlag,
+		Output:     *outputFlag,
+		OnExisting: *onExistingFlag,
+		Yes:        *yesFlag,
+		Fast:       *fastFlag,
+		Docker:     *docker,
+		Resume:     *resumeFlag,
+		Timeout:    *timeoutFlag,
+		Retries:    *retriesFlag,
+		SQLite:     *sqliteFlag,
+	})
+}
+
+// Run the flags of the previous versions : golc -devops <target> [OPTIONS]
+func legacyCommand(args []string) {
+	fs := flag.NewFlagSet("golc", flag.ExitOnError)
+	devopsFlag := fs.String("devops", "", "Specify the DevOps platform (same as: golc scan <target>)")
+	fastFlag := fs.Bool("fast", false, "Enable fast mode (only for Github)")
+	helpFlag := fs.Bool("help", false, "Show help message")
+	languagesFlag := fs.Bool("languages", false, "Show all supported languages")
+	versionflag := fs.Bool("version", false, "Show version")
+	docker := fs.Bool("docker", false, "Run in Docker mode")
+	outputFlag := addOutputFlag(fs)
+	onExistingFlag := fs.String("on-existing", "", "What to do if the output directory exists: <backup>||<overwrite>||<fail>||<timestamp>")
+	yesFlag := fs.Bool("yes", false, "Do not prompt, assume yes (same as -on-existing backup when not set)")
+	resumeFlag := fs.Bool("resume", false, "Resume an interrupted analysis of the output directory, skipping the repositories already analysed at the same commit")
+	timeoutFlag, retriesFlag := addRetryFlags(fs)
+	fs.Usage = func() {
+		usage()
+		fmt.Println("\nOptions of previous versions: golc -devops <target> [OPTIONS]")
+		fs.PrintDefaults()
+	}
+
+	fs.Parse(args)
+
+	switch {
+	case *helpFlag:
+		fs.Usage()
+	case *languagesFlag:
+		displayLanguages()
+	case *versionflag:
+		fmt.Printf("GoLC version: %s %s/%s\n", version, runtime.GOOS, runtime.GOARCH)
+	case *devopsFlag == "":
+		fmt.Printf("\n❌ Please specify the DevOps platform : %s\n", platformTargets)
+		fmt.Println("✅ Example for BitBucket server : golc scan BitBucketSRV")
+		os.Exit(1)
+	default:
+		runScan(scanOptions{
+			Target:     *devopsFlag,
+			ConfigFile: "config.json",
+			Output:     *outputFlag,
+			OnExisting: *onExistingFlag,
+			Yes:        *yesFlag,
+			Fast:       *fastFlag,
+			Docker:     *docker,
+			Resume:     *resumeFlag,
+			Timeout:    *timeoutFlag,
+			Retries:    *retriesFlag,
+		})
+	}
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(1)
+	}
+
+	name := os.Args[1]
+	if strings.HasPrefix(name, "-") {
+		legacyCommand(os.Args[1:])
+		return
+	}
+
+	if name == "help" {
+		usage()
+		return
+	}
+
+	for _, cmd := range commands() {
+		if cmd.Name == name {
+			cmd.Run(os.Args[2:])
+			return
+		}
+	}
 
+	fmt.Printf("❌ Unknown command '%s'\n\n", name)
+	usage()
+	os.Exit(1)
 }
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/colussim/GoLC/pkg/journal"
+)
+
+func TestResultFileName(t *testing.T) {
+	tests := []struct {
+		name   string
+		params RepoParams
+		prefix string
+	}{
+		{"project", RepoParams{ProjectKey: "PRJ", RepoSlug: "api", MainBranch: "main"}, "Result_PRJ_api_main_"},
+		{"namespace", RepoParams{ProjectKey: "grp", Namespace: "grp/sub/api", RepoSlug: "api", MainBranch: "main"}, "Result_grp_sub_api_main_"},
+		{"branch with a slash", RepoParams{ProjectKey: "PRJ", RepoSlug: "api", MainBranch: "feat/x"}, "Result_PRJ_api_feat_x_"},
+	}
+	hash := regexp.MustCompile(`^[0-9a-f]{8}$`)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name := resultFileName(tt.params)
+			if len(name) != len(tt.prefix)+8 || name[:len(tt.prefix)] != tt.prefix || !hash.MatchString(name[len(tt.prefix):]) {
+				t.Errorf("resultFileName = %s, want %s and a short hash", name, tt.prefix)
+			}
+			if resultFileName(tt.params) != name {
+				t.Error("the name of a repository changes between two calls")
+			}
+		})
+	}
+}
+
+func TestResultFileNameCollisions(t *testing.T) {
+	// Repositories whose readable names are the same once the / are flattened
+	pairs := [][2]RepoParams{
+		{
+			{ProjectKey: "grp", Namespace: "grp/a_b", RepoSlug: "a_b", MainBranch: "main"},
+			{ProjectKey: "grp", Namespace: "grp/a", RepoSlug: "a", MainBranch: "b_main"},
+		},
+		{
+			{ProjectKey: "PRJ", RepoSlug: "api", MainBranch: "feat/x"},
+			{ProjectKey: "PRJ", RepoSlug: "api", MainBranch: "feat_x"},
+		},
+		{
+			{ProjectKey: "PRJ_a", RepoSlug: "b", MainBranch: "main"},
+			{ProjectKey: "PRJ", RepoSlug: "a_b", MainBranch: "main"},
+		},
+	}
+	for _, pair := range pairs {
+		if a, b := resultFileName(pair[0]), resultFileName(pair[1]); a == b {
+			t.Errorf("%+v and %+v are both written in %s", pair[0], pair[1], a)
+		}
+	}
+}
+
+func TestResultFilesClaim(t *testing.T) {
+	files := newResultFiles([]journal.Entry{
+		{ProjectKey: "PRJ", RepoSlug: "api", Branch: "main", ResultFile: "Result_api.json"},
+	})
+
+	// The repository of a resumed run writes its file again
+	if err := files.claim("Result_api.json", resultKey("PRJ", "", "api", "main")); err != nil {
+		t.Errorf("claim of its own file: %v", err)
+	}
+	if err := files.claim("Result_api.json", resultKey("PRJ", "", "api", "develop")); err == nil {
+		t.Error("no error when another branch writes the file of the journal")
+	}
+
+	if err := files.claim("Result_src.json", "/a/src"); err != nil {
+		t.Fatal(err)
+	}
+	if err := files.claim("Result_src.json", "/b/src"); err == nil {
+		t.Error("no error when two directories write the same file")
+	}
+}
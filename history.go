@@ -0,0 +1,193 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/colussim/GoLC/pkg/report"
+	"github.com/colussim/GoLC/pkg/store"
+	"github.com/olekukonko/tablewriter"
+)
+
+func historyCommand(args []string) {
+	fs := newFlagSet("history", "", "Show the growth of the code over the runs recorded in a SQLite database")
+	dbFlag := fs.String("db", "golc.db", "Path of the SQLite database")
+	orgFlag := fs.String("org", "", "Organization, the organizations are compared without it when the database holds several")
+	byFlag := fs.String("by", "", "Also show the code lines by project, repo or language")
+	runsFlag := fs.Int("runs", 5, "Number of latest runs shown as columns with -by")
+	jsonFlag := fs.Bool("json", false, "Write the history as JSON")
+
+	positional := parseArgs(fs, args)
+	requireArgs(fs, positional, 0)
+
+	db, err := store.Open(*dbFlag)
+	if err != nil {
+		fmt.Println("❌ Error opening database:", err)
+		os.Exit(1)
+	}
+	defer db.Close()
+
+	if *orgFlag == "" && !*jsonFlag {
+		organizations, err := db.Organizations()
+		if err != nil {
+			fmt.Println("❌ Error reading the history:", err)
+			os.Exit(1)
+		}
+		if len(organizations) > 1 {
+			if err := printOrganizations(db); err != nil {
+				fmt.Println("❌ Error reading the history:", err)
+				os.Exit(1)
+			}
+			fmt.Println("\nℹ️  Use -org to show the history of an organization")
+			return
+		}
+	}
+
+	history, err := db.History(*orgFlag, *byFlag)
+	if err != nil {
+		fmt.Println("❌ Error reading the history:", err)
+		os.Exit(1)
+	}
+
+	if *jsonFlag {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(history)
+		return
+	}
+
+	if len(history.Runs) == 0 {
+		fmt.Printf("❗️ No run recorded in %s\n", *dbFlag)
+		return
+	}
+
+	printRuns(history)
+	if *byFlag != "" {
+		printSeries(history, *runsFlag)
+	}
+}
+
+// Compare the first and the last run of every organization
+func printOrganizations(db *store.Store) error {
+	runs, err := db.Runs()
+	if err != nil {
+		return err
+	}
+
+	type growth struct {
+		runs        int
+		first, last report.RunRecord
+	}
+	var names []string
+	organizations := make(map[string]*growth)
+	// The runs are listed the latest first
+	for _, run := range runs {
+		g := organizations[run.Organization]
+		if g == nil {
+			g = &growth{last: run}
+			organizations[run.Organization] = g
+			names = append(names, run.Organization)
+		}
+		g.runs++
+		g.first = run
+	}
+
+	table := newHistoryTable([]string{"Organization", "Runs", "First run", "Last run", "First code lines", "Last code lines", "Delta"})
+	for _, name := range names {
+		g := organizations[name]
+		table.Append([]string{
+			name,
+			strconv.Itoa(g.runs),
+			g.first.Time.Format("2006-01-02"),
+			g.last.Time.Format("2006-01-02"),
+			strconv.Itoa(g.first.CodeLines),
+			strconv.Itoa(g.last.CodeLines),
+			fmt.Sprintf("%+d", g.last.CodeLines-g.first.CodeLines),
+		})
+	}
+	table.Render()
+	return nil
+}
+
+// Show the runs of an organization with the repositories added and removed at each run
+func printRuns(history *report.History) {
+	fmt.Printf("✅ Runs of %s\n\n", history.Organization)
+
+	table := newHistoryTable([]string{"Run", "Date", "Platform", "Repositories", "Code lines", "Delta", "Added", "Removed"})
+	for i, run := range history.Runs {
+		delta, added, removed := "", "", ""
+		if i > 0 {
+			change := history.Changes[i-1]
+			delta = fmt.Sprintf("%+d", run.CodeLines-history.Runs[i-1].CodeLines)
+			added, removed = strconv.Itoa(len(change.Added)), strconv.Itoa(len(change.Removed))
+		}
+		table.Append([]string{
+			strconv.FormatInt(run.ID, 10),
+			run.Time.Format("2006-01-02 15:04"),
+			run.Platform,
+			strconv.Itoa(run.Repositories),
+			strconv.Itoa(run.CodeLines),
+			delta,
+			added,
+			removed,
+		})
+	}
+	table.Render()
+
+	for i, change := range history.Changes {
+		if len(change.Added) == 0 && len(change.Removed) == 0 {
+			continue
+		}
+		fmt.Printf("\n✅ Run %d (%s)\n", change.RunID, history.Runs[i+1].Time.Format("2006-01-02 15:04"))
+		if len(change.Added) > 0 {
+			fmt.Println("\tAdded   :", strings.Join(change.Added, ", "))
+		}
+		if len(change.Removed) > 0 {
+			fmt.Println("\tRemoved :", strings.Join(change.Removed, ", "))
+		}
+	}
+}
+
+// Header of the first column of the series
+var historyColumns = map[string]string{
+	report.HistoryProject:    "Project",
+	report.HistoryRepository: "Repository",
+	report.HistoryLanguage:   "Language",
+}
+
+// Show the code lines of the series at the latest runs
+func printSeries(history *report.History, last int) {
+	first := 0
+	if last > 0 && len(history.Runs) > last {
+		first = len(history.Runs) - last
+	}
+
+	header := []string{historyColumns[history.By]}
+	for _, run := range history.Runs[first:] {
+		header = append(header, run.Time.Format("2006-01-02"))
+	}
+	header = append(header, "Delta")
+
+	fmt.Println()
+	table := newHistoryTable(header)
+	for _, serie := range history.Series {
+		row := []string{serie.Name}
+		for _, codeLines := range serie.CodeLines[first:] {
+			row = append(row, strconv.Itoa(codeLines))
+		}
+		row = append(row, fmt.Sprintf("%+d", serie.CodeLines[len(serie.CodeLines)-1]-serie.CodeLines[first]))
+		table.Append(row)
+	}
+	table.Render()
+}
+
+func newHistoryTable(header []string) *tablewriter.Table {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader(header)
+	table.SetBorder(false)
+	table.SetAutoFormatHeaders(false)
+	return table
+}
@@ -0,0 +1,9 @@
+// Package imgs embeds the images of the PDF report
+package imgs
+
+import "embed"
+
+// Logo.png is drawn on dark backgrounds, Logob.png on light ones
+//
+//go:embed Logo.png Logob.png
+var FS embed.FS
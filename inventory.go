@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/colussim/GoLC/pkg/devops/inventory"
+)
+
+func inventoryCommand(args []string) {
+	fs := newFlagSet("inventory", "<target>", "Write the decision log of the repositories selected or skipped for analysis, without cloning them")
+	configFlag := addConfigFlag(fs)
+	outputFlag := addOutputFlag(fs)
+	formatFlag := fs.String("format", "json", "Format of the decision log: <json>||<csv>")
+	fileFlag := fs.String("file", "", "Path of the decision log (default <output>/inventory.<format>)")
+
+	positional := parseArgs(fs, args)
+	requireArgs(fs, positional, 1)
+
+	if *formatFlag != "json" && *formatFlag != "csv" {
+		fmt.Printf("❌ Invalid -format value '%s'. Use json or csv\n", *formatFlag)
+		os.Exit(1)
+	}
+
+	platformConfig, cleanup := loadTargetConfig(*configFlag, positional[0])
+	defer cleanup()
+
+	inv := inventory.NewLog()
+	platformConfig[inventory.ConfigKey] = inv
+
+	if platformConfig["DevOps"].(string) == "file" {
+		ListDirectory, _, err := loadFileDirectories(platformConfig)
+		if err != nil {
+			fmt.Printf("❌ %s\n", err)
+			cleanup()
+			os.Exit(1)
+		}
+		addSelectedDirs(inv, ListDirectory)
+	} else {
+		repolist, err := selectRepos(platformConfig)
+		if err != nil {
+			fmt.Printf("❌ %s\n", err)
+			cleanup()
+			os.Exit(1)
+		}
+		addSelectedRepos(inv, platformConfig, repolist)
+	}
+
+	logFile := *fileFlag
+	if logFile == "" {
+		logFile = filepath.Join(*outputFlag, "inventory."+*formatFlag)
+	}
+	if err := os.MkdirAll(filepath.Dir(logFile), os.ModePerm); err != nil {
+		fmt.Println("❌ Error creating inventory directory:", err)
+		cleanup()
+		os.Exit(1)
+	}
+
+	file, err := os.Create(logFile)
+	if err != nil {
+		fmt.Println("❌ Error creating inventory file:", err)
+		cleanup()
+		os.Exit(1)
+	}
+	defer file.Close()
+
+	if *formatFlag == "csv" {
+		err = inv.WriteCSV(file)
+	} else {
+		err = inv.WriteJSON(file)
+	}
+	if err != nil {
+		fmt.Println("❌ Error writing inventory file:", err)
+		cleanup()
+		os.Exit(1)
+	}
+
+	counts := inv.Counts()
+	statuses := make([]string, 0, len(counts))
+	for status := range counts {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+
+	fmt.Println()
+	for _, status := range statuses {
+		fmt.Printf("✅ %-14s : %d\n", status, counts[status])
+	}
+	fmt.Printf("\n✅ Inventory recorded in %s\n", logFile)
+}
+
+// Record the directories of the File platform as selected
+func addSelectedDirs(inv *inventory.Log, dirs []string) {
+	for _, dir := range dirs {
+		inv.Add("", dir, "", inventory.Selected, "directory of the configuration")
+	}
+}
+
+// Record the repositories selected for the analysis with the reason of their branch
+func addSelectedRepos(inv *inventory.Log, platformConfig map[string]interface{}, repolist []RepoParams) {
+	reason := "largest branch"
+	if platformConfig["DefaultBranch"].(bool) {
+		reason = "default branch"
+	}
+	for _, repo := range repolist {
+		inv.Add(repo.ProjectKey, repo.RepoSlug, repo.MainBranch, inventory.Selected, reason)
+	}
+}
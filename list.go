@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// Load the configuration of a platform for the commands which do not analyse it.
+// The connectors save their selection in the config directory of the results, so they get a
+// temporary directory removed by the returned function, and the results of the last scan are not modified.
+func loadTargetConfig(configFile, target string) (map[string]interface{}, func()) {
+	platformConfig, err := loadPlatformConfig(configFile, target)
+	if err != nil {
+		fmt.Printf("\n❌ %s\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("\n✅ Using configuration for DevOps platform '%s'\n", target)
+
+	resultsDir, err := os.MkdirTemp("", "golc-dryrun-")
+	if err != nil {
+		fmt.Println("❌ Error creating temporary directory:", err)
+		os.Exit(1)
+	}
+	if err := os.MkdirAll(filepath.Join(resultsDir, directoryconf), os.ModePerm); err != nil {
+		os.RemoveAll(resultsDir)
+		fmt.Println("❌ Error creating temporary directory:", err)
+		os.Exit(1)
+	}
+	platformConfig["ResultsDir"] = resultsDir
+
+	return platformConfig, func() { os.RemoveAll(resultsDir) }
+}
+
+func listReposCommand(args []string) {
+	fs := newFlagSet("list-repos", "<target>", "List the repositories and branches selected for analysis, without analysing them")
+	configFlag := addConfigFlag(fs)
+
+	positional := parseArgs(fs, args)
+	requireArgs(fs, positional, 1)
+
+	platformConfig, cleanup := loadTargetConfig(*configFlag, positional[0])
+	defer cleanup()
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetBorder(false)
+	table.SetAutoFormatHeaders(false)
+
+	if platformConfig["DevOps"].(string) == "file" {
+		ListDirectory, ListExclusion, err := loadFileDirectories(platformConfig)
+		if err != nil {
+			fmt.Printf("❌ %s\n", err)
+			cleanup()
+			os.Exit(1)
+		}
+
+		table.SetHeader([]string{"Directory", "Excluded paths"})
+		for _, dir := range ListDirectory {
+			table.Append([]string{dir, fmt.Sprintf("%v", ListExclusion)})
+		}
+		fmt.Println()
+		table.Render()
+		return
+	}
+
+	repolist, err := selectRepos(platformConfig)
+	if err != nil {
+		fmt.Printf("❌ %s\n", err)
+		cleanup()
+		os.Exit(1)
+	}
+
+	table.SetHeader([]string{"Project", "Repository", "Branch"})
+	for _, repo := range repolist {
+		table.Append([]string{repo.ProjectKey, repo.RepoSlug, repo.MainBranch})
+	}
+	table.SetFooter([]string{"", "Total", fmt.Sprintf("%d", len(repolist))})
+
+	fmt.Println()
+	table.Render()
+}
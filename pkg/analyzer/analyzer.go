@@ -1,6 +1,7 @@
 package analyzer
 
 import (
+	"context"
 	"io/fs"
 	"path/filepath"
 	"strings"
@@ -37,12 +38,20 @@ func NewAnalyzer(
 }
 
 func (a *Analyzer) MatchingFiles() ([]FileMetadata, error) {
+	return a.MatchingFilesContext(context.Background())
+}
+
+// MatchingFilesContext is MatchingFiles stopping the walk when ctx is done
+func (a *Analyzer) MatchingFilesContext(ctx context.Context) ([]FileMetadata, error) {
 	var files []FileMetadata
 
 	err := filepath.Walk(a.path, func(path string, info fs.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 
 		if info.IsDir() {
 			return nil
@@ -6,10 +6,12 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/briandowns/spinner"
+	"github.com/colussim/GoLC/pkg/devops/inventory"
 	"github.com/colussim/GoLC/pkg/utils"
 	"github.com/microsoft/azure-devops-go-api/azuredevops"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/core"
@@ -74,6 +76,7 @@ type ParamsProjectAzure struct {
 	DefaultB       bool
 	SingleRepos    string
 	SingleBranch   string
+	Inventory      *inventory.Log
 }
 
 // RepositoryMap represents a map of repositories to ignore
@@ -124,7 +127,22 @@ func isRepoEmpty(ctx context.Context, gitClient git.Client, projectID string, re
 	return len(*items) == 0, nil
 }
 
-func getAllProjects(ctx context.Context, coreClient core.Client, exclusionList *utils.ExclusionList) ([]core.TeamProjectReference, int, error) {
+// Return the inventory status of an Azure DevOps API error
+func apiErrorStatus(err error) string {
+	switch e := err.(type) {
+	case azuredevops.WrappedError:
+		if e.StatusCode != nil {
+			return inventory.ErrorStatus(*e.StatusCode)
+		}
+	case *azuredevops.WrappedError:
+		if e.StatusCode != nil {
+			return inventory.ErrorStatus(*e.StatusCode)
+		}
+	}
+	return inventory.Error
+}
+
+func getAllProjects(ctx context.Context, coreClient core.Client, exclusionList *utils.ExclusionList, inv *inventory.Log) ([]core.TeamProjectReference, int, error) {
 	var allProjects []core.TeamProjectReference
 	var excludedCount int
 	var continuationToken string
@@ -140,6 +158,7 @@ func getAllProjects(ctx context.Context, coreClient core.Client, exclusionList *
 
 		for _, project := range responseValue.Value {
 			if isProjectExcluded(exclusionList, *project.Name) {
+				inv.Add(*project.Name, "", "", inventory.Excluded, "project is in the exclusion file")
 				excludedCount++
 				continue
 			}
@@ -240,7 +259,7 @@ func GetRepoAzureList(platformConfig map[string]interface{}, exclusionFile strin
 	if platformConfig["Project"].(string) == "" {
 
 		// Get All Project
-		projects, exludedprojects, err := getAllProjects(ctx, coreClient, exclusionList)
+		projects, exludedprojects, err := getAllProjects(ctx, coreClient, exclusionList, inventory.FromConfig(platformConfig))
 
 		if err != nil {
 			spin.Stop()
@@ -300,7 +319,7 @@ func GetRepoAzureList(platformConfig map[string]interface{}, exclusionFile strin
 		NumRepositories: nbRepos,
 		ProjectBranches: importantBranches,
 	}
-	if err := SaveResult(result); err != nil {
+	if err := SaveResult(result, platformConfig["ResultsDir"].(string)); err != nil {
 		fmt.Println("❌ Error Save Result of Analysis :", err)
 		os.Exit(1)
 	}
@@ -338,6 +357,7 @@ func getCommonParams(azureConnect AzureConnect, platformConfig map[string]interf
 		DefaultB:       platformConfig["DefaultBranch"].(bool),
 		SingleRepos:    platformConfig["Repos"].(string),
 		SingleBranch:   platformConfig["Branch"].(string),
+		Inventory:      inventory.FromConfig(platformConfig),
 	}
 }
 
@@ -399,6 +419,7 @@ func getRepoAnalyse(params ParamsProjectAzure, gitClient git.Client) ([]ProjectB
 		emptyOrArchivedCount, emptyRepos, excludedCount, repos, err := listReposForProject(params, *project.Name, gitClient)
 
 		if err != nil {
+			params.Inventory.Add(*project.Name, "", "", apiErrorStatus(err), err.Error())
 			if len(params.SingleRepos) == 0 {
 				fmt.Println("\r❌ Get Repos for each Project:", err)
 				spin1.Stop()
@@ -428,6 +449,7 @@ func getRepoAnalyse(params ParamsProjectAzure, gitClient git.Client) ([]ProjectB
 			if err != nil {
 				if params.SingleBranch != "" {
 					// Skip this repository if SingleBranch is set but not found
+					params.Inventory.Add(*project.Name, *repo.Name, params.SingleBranch, inventory.NoBranch, "branch does not exist in the repository")
 					continue
 				}
 				largestRepoBranch = *repo.DefaultBranch
@@ -435,6 +457,7 @@ func getRepoAnalyse(params ParamsProjectAzure, gitClient git.Client) ([]ProjectB
 				// Check if SingleBranch is set and the returned branch is not SingleBranch
 				if params.SingleBranch != "" && !params.DefaultB && largestRepoBranch != params.SingleBranch {
 					// Skip this repository if the most important branch is not the SingleBranch
+					params.Inventory.Add(*project.Name, *repo.Name, largestRepoBranch, inventory.NoBranch, fmt.Sprintf("main branch is not %s", params.SingleBranch))
 					continue
 				}
 			}
@@ -488,6 +511,7 @@ func listReposForProject(parms ParamsProjectAzure, projectKey string, gitClient
 
 		// check if exclude
 		if isRepoExcluded(parms.Exclusionlist, projectKey, repoName) {
+			parms.Inventory.Add(projectKey, repoName, "", inventory.Excluded, "repository is in the exclusion file")
 			excludedCount++
 			continue
 		}
@@ -498,6 +522,7 @@ func listReposForProject(parms ParamsProjectAzure, projectKey string, gitClient
 			return 0, 0, 0, nil, err
 		}
 		if isEmpty {
+			parms.Inventory.Add(projectKey, repoName, "", inventory.Empty, "repository has no file")
 			emptyCount++
 			continue
 		}
@@ -694,9 +719,10 @@ func getCommitCount(ctx context.Context, gitClient git.Client, projectID string,
 
 	return totalCommits, nil
 }
-func SaveResult(result AnalysisResult) error {
+func SaveResult(result AnalysisResult, resultsDir string) error {
 	// Open or create the file
-	file, err := os.Create("Results/config/analysis_result_azure.json")
+	filePath := filepath.Join(resultsDir, "config", "analysis_result_azure.json")
+	file, err := os.Create(filePath)
 	if err != nil {
 		fmt.Println("❌ Error creating Analysis file:", err)
 		return err
@@ -708,7 +734,7 @@ func SaveResult(result AnalysisResult) error {
 
 	// Encode the result and write it to the file
 	if err := encoder.Encode(result); err != nil {
-		fmt.Println("❌ Error encoding JSON file <Results/config/analysis_result_azure.json> :", err)
+		fmt.Printf("❌ Error encoding JSON file <%s> : %v\n", filePath, err)
 		return err
 	}
 
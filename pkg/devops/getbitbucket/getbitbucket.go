@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"time"
@@ -147,6 +148,7 @@ type ParamsReposProjectCloud struct {
 	ExclusionList    *ExclusionList
 	Spin             *spinner.Spinner
 	Branch           string
+	ResultsDir       string
 }
 
 type ParamsReposCloud struct {
@@ -160,6 +162,7 @@ type ParamsReposCloud struct {
 	Workspace        string
 	ExclusionList    *ExclusionList
 	Branch           string
+	ResultsDir       string
 }
 
 type SizeResponse struct {
@@ -348,7 +351,8 @@ func GetReposProjectCloud(parms ParamsReposProjectCloud) ([]ProjectBranch, int,
 	result.ProjectBranches = importantBranches
 
 	// Save Result of Analysis
-	file, err := os.Create("Results/config/analysis_repos.json")
+	filePath := filepath.Join(parms.ResultsDir, "config", "analysis_repos.json")
+	file, err := os.Create(filePath)
 	if err != nil {
 		fmt.Println("❌ Error creating Analysis file:", err)
 		return importantBranches, parms.NBRepos, emptyRepo
@@ -358,7 +362,7 @@ func GetReposProjectCloud(parms ParamsReposProjectCloud) ([]ProjectBranch, int,
 
 	err = encoder.Encode(result)
 	if err != nil {
-		fmt.Println("Error encoding JSON file <Results/config/analysis_repos.json> :", err)
+		fmt.Printf("Error encoding JSON file <%s> : %v\n", filePath, err)
 		return importantBranches, parms.NBRepos, emptyRepo
 	}
 	return importantBranches, parms.NBRepos, emptyRepo
@@ -473,7 +477,8 @@ func GetRepos(parms ParamsReposCloud) ([]ProjectBranch, int, int) {
 	result.ProjectBranches = importantBranches
 
 	// Save Result of Analysis
-	file, err := os.Create("Results/config/analysis_repos.json")
+	filePath := filepath.Join(parms.ResultsDir, "config", "analysis_repos.json")
+	file, err := os.Create(filePath)
 	if err != nil {
 		fmt.Println("❌ Error creating Analysis file:", err)
 		return importantBranches, nbRepos, emptyRepo
@@ -483,7 +488,7 @@ func GetRepos(parms ParamsReposCloud) ([]ProjectBranch, int, int) {
 
 	err = encoder.Encode(result)
 	if err != nil {
-		fmt.Println("Error encoding JSON file <Results/config/analysis_repos.json> :", err)
+		fmt.Printf("Error encoding JSON file <%s> : %v\n", filePath, err)
 		return importantBranches, nbRepos, emptyRepo
 	}
 
@@ -491,7 +496,7 @@ func GetRepos(parms ParamsReposCloud) ([]ProjectBranch, int, int) {
 
 }
 
-func GetProjectBitbucketListCloud(url, baseapi, apiver, accessToken, workspace, exlusionfile, project, repo, branchmain string) ([]ProjectBranch, error) {
+func GetProjectBitbucketListCloud(url, baseapi, apiver, accessToken, workspace, exlusionfile, project, repo, branchmain, resultsDir string) ([]ProjectBranch, error) {
 
 	var largestRepoSize int
 	var totalSize int
@@ -551,6 +556,7 @@ func GetProjectBitbucketListCloud(url, baseapi, apiver, accessToken, workspace,
 			ExclusionList:    exclusionList,
 			Spin:             spin,
 			Branch:           branchmain,
+			ResultsDir:       resultsDir,
 		}
 
 		importantBranches, nbRepos, emptyRepo = GetReposProjectCloud(parms)
@@ -590,6 +596,7 @@ func GetProjectBitbucketListCloud(url, baseapi, apiver, accessToken, workspace,
 					ExclusionList:    exclusionList,
 					Spin:             spin,
 					Branch:           branchmain,
+					ResultsDir:       resultsDir,
 				}
 				importantBranches, nbRepos, emptyRepo = GetReposProjectCloud(parms)
 
@@ -621,6 +628,7 @@ func GetProjectBitbucketListCloud(url, baseapi, apiver, accessToken, workspace,
 				Workspace:        workspace,
 				ExclusionList:    exclusionList,
 				Branch:           branchmain,
+				ResultsDir:       resultsDir,
 			}
 
 			importantBranches, nbRepos, emptyRepo = GetRepos(parms)
@@ -6,10 +6,12 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/briandowns/spinner"
+	"github.com/colussim/GoLC/pkg/devops/inventory"
 	"github.com/colussim/GoLC/pkg/utils"
 	"github.com/ktrysmt/go-bitbucket"
 )
@@ -78,6 +80,7 @@ type ParamsProjectBitbucket struct {
 	DefaultB         bool
 	SingleRepos      string
 	SingleBranch     string
+	Inventory        *inventory.Log
 }
 
 type Response1 struct {
@@ -159,7 +162,7 @@ func GetProjectBitbucketListCloud(platformConfig map[string]interface{}, exclusi
 
 	if len(project) == 0 && len(repos) == 0 {
 		// Get All Project
-		projects, exludedprojects, err = getAllProjects(client, platformConfig["Workspace"].(string), exclusionList)
+		projects, exludedprojects, err = getAllProjects(client, platformConfig["Workspace"].(string), exclusionList, inventory.FromConfig(platformConfig))
 		if err != nil {
 			fmt.Println("\r❌ Error Get All Projects:", err)
 			spin.Stop()
@@ -188,7 +191,7 @@ func GetProjectBitbucketListCloud(platformConfig map[string]interface{}, exclusi
 		NumRepositories: nbRepos,
 		ProjectBranches: importantBranches,
 	}
-	if err := SaveResult(result); err != nil {
+	if err := SaveResult(result, platformConfig["ResultsDir"].(string)); err != nil {
 		fmt.Println("❌ Error Save Result of Analysis :", err)
 		os.Exit(1)
 	}
@@ -297,10 +300,11 @@ func getCommonParams(client *bitbucket.Client, platformConfig map[string]interfa
 		DefaultB:         platformConfig["DefaultBranch"].(bool),
 		SingleRepos:      platformConfig["Repos"].(string),
 		SingleBranch:     platformConfig["Branch"].(string),
+		Inventory:        inventory.FromConfig(platformConfig),
 	}
 }
 
-func getAllProjects(client *bitbucket.Client, workspace string, exclusionList *utils.ExclusionList) ([]Projectc, int, error) {
+func getAllProjects(client *bitbucket.Client, workspace string, exclusionList *utils.ExclusionList, inv *inventory.Log) ([]Projectc, int, error) {
 
 	var projects []Projectc
 	var excludedCount int
@@ -312,6 +316,7 @@ func getAllProjects(client *bitbucket.Client, workspace string, exclusionList *u
 
 	for _, project := range projectsRes.Items {
 		if isProjectExcluded(exclusionList, project.Key) {
+			inv.Add(project.Key, "", "", inventory.Excluded, "project is in the exclusion file")
 			excludedCount++
 			continue
 		}
@@ -397,6 +402,7 @@ func getRepoAnalyse(params ParamsProjectBitbucket) ([]ProjectBranch, int, int, i
 
 		emptyOrArchivedCount, excludedCount, repos, err := listReposForProject(params, project.Key)
 		if err != nil {
+			params.Inventory.Add(project.Key, params.SingleRepos, "", inventory.Error, err.Error())
 			if len(params.SingleRepos) == 0 {
 				fmt.Println("\r❌ Get Repos for each Project:", err)
 				spin1.Stop()
@@ -488,6 +494,7 @@ func listRepos(parms ParamsProjectBitbucket, projectKey string, reposRes *bitbuc
 		for _, repo := range reposRes.Items {
 			repoCopy := repo
 			if isRepoExcluded(parms.Exclusionlist, projectKey, repo.Slug) {
+				parms.Inventory.Add(projectKey, repo.Slug, "", inventory.Excluded, "repository is in the exclusion file")
 				excludedCount++
 				continue
 			}
@@ -497,6 +504,7 @@ func listRepos(parms ParamsProjectBitbucket, projectKey string, reposRes *bitbuc
 				fmt.Printf("❌ Error when Testing if repo is empty %s: %v\n", repo.Slug, err)
 			}
 			if isEmpty {
+				parms.Inventory.Add(projectKey, repo.Slug, repo.Mainbranch.Name, inventory.Empty, "repository has no file")
 				emptyOrArchivedCount++
 				continue
 			}
@@ -765,9 +773,10 @@ func getCommitsForLastMonth(client *bitbucket.Client, workspace, repoSlug, branc
 	return recentCommits, nil
 }
 
-func SaveResult(result AnalysisResult) error {
+func SaveResult(result AnalysisResult, resultsDir string) error {
 	// Open or create the file
-	file, err := os.Create("Results/config/analysis_result_bitbucket.json")
+	filePath := filepath.Join(resultsDir, "config", "analysis_result_bitbucket.json")
+	file, err := os.Create(filePath)
 	if err != nil {
 		fmt.Println("❌ Error creating Analysis file:", err)
 		return err
@@ -779,7 +788,7 @@ func SaveResult(result AnalysisResult) error {
 
 	// Encode the result and write it to the file
 	if err := encoder.Encode(result); err != nil {
-		fmt.Println("❌ Error encoding JSON file <Results/config/analysis_result_bitbucket.json> :", err)
+		fmt.Printf("❌ Error encoding JSON file <%s> : %v\n", filePath, err)
 		return err
 	}
 
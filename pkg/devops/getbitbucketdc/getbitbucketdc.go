@@ -7,11 +7,13 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/briandowns/spinner"
+	"github.com/colussim/GoLC/pkg/devops/inventory"
 	"github.com/colussim/GoLC/pkg/utils"
 )
 
@@ -105,6 +107,8 @@ type ParamsReposDC struct {
 	Branch           string
 	Spin             *spinner.Spinner
 	DefaultB         bool
+	ResultsDir       string
+	Inventory        *inventory.Log
 }
 
 type BranchResponse struct {
@@ -177,6 +181,8 @@ type ParamsReposProjectDC struct {
 	Spin             *spinner.Spinner
 	Branch           string
 	DefaultB         bool
+	ResultsDir       string
+	Inventory        *inventory.Log
 }
 
 type FetchParams struct {
@@ -194,6 +200,25 @@ const Startopt = "%s?start=%d"
 
 var ErrEmptyRepo = errors.New("repository is empty")
 
+// Error of a Bitbucket DC API call which did not return 200
+type statusError struct {
+	URL        string
+	StatusCode int
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("%s returned status %d", e.URL, e.StatusCode)
+}
+
+// Return the inventory status of a Bitbucket DC API error
+func apiErrorStatus(err error) string {
+	var se *statusError
+	if errors.As(err, &se) {
+		return inventory.ErrorStatus(se.StatusCode)
+	}
+	return inventory.Error
+}
+
 func GetReposProject(projects []Project, parms ParamsReposProjectDC, bitbucketURLBase string, nbRepos int, exclusionList *utils.ExclusionList) ([]ProjectBranch, int, int) {
 	var importantBranches []ProjectBranch
 	emptyRepo := 0
@@ -211,8 +236,9 @@ func GetReposProject(projects []Project, parms ParamsReposProjectDC, bitbucketUR
 		fmt.Printf("\n\t🟢  Analyse Projet: %s \n", project.Name)
 		urlrepos := fmt.Sprintf("%s%s%s/projects/%s/repos", parms.URL, parms.BaseAPI, parms.APIVersion, project.Key)
 
-		repos, err := fetchAllRepos(urlrepos, parms.AccessToken, exclusionList)
+		repos, err := fetchAllRepos(urlrepos, parms.AccessToken, exclusionList, parms.Inventory)
 		if err != nil {
+			parms.Inventory.Add(project.Key, "", "", apiErrorStatus(err), err.Error())
 			fmt.Println("\r❌ Get Repos for each Project:", err)
 			continue
 		}
@@ -223,8 +249,10 @@ func GetReposProject(projects []Project, parms ParamsReposProjectDC, bitbucketUR
 		for _, repo := range repos {
 			if err := processRepo(project.Key, repo, parms, bitbucketURLBase, spin1, &importantBranches); err != nil {
 				if err == ErrEmptyRepo {
+					parms.Inventory.Add(project.Key, repo.Slug, "", inventory.Empty, "repository has no file")
 					emptyRepo++
 				} else {
+					parms.Inventory.Add(project.Key, repo.Slug, "", apiErrorStatus(err), err.Error())
 					fmt.Printf("❌ Error processing repo %s: %v\n", repo.Name, err)
 				}
 			}
@@ -235,7 +263,7 @@ func GetReposProject(projects []Project, parms ParamsReposProjectDC, bitbucketUR
 	result.NumRepositories = nbRepos
 	result.ProjectBranches = importantBranches
 
-	if err := saveAnalysisResult1("Results/config/analysis_repos.json", result); err != nil {
+	if err := saveAnalysisResult1(filepath.Join(parms.ResultsDir, "config", "analysis_repos.json"), result); err != nil {
 		fmt.Println("❌ Error creating Analysis file:", err)
 		return importantBranches, nbRepos, emptyRepo
 	}
@@ -261,6 +289,7 @@ func processRepo(projectKey string, repo Repo, parms ParamsReposProjectDC, bitbu
 
 	if len(branches) == 0 {
 		fmt.Printf("❗️ No branches found for repository %s\n", repo.Slug)
+		parms.Inventory.Add(projectKey, repo.Slug, parms.Branch, inventory.NoBranch, "no branch found in the repository")
 		return nil
 	}
 
@@ -351,6 +380,7 @@ func GetRepos(project string, repos []Repo, parms ParamsReposDC, bitbucketURLBas
 
 		if isEmpty {
 			fmt.Println("❌ Repo is empty:", repo.Name)
+			parms.Inventory.Add(project, repo.Slug, "", inventory.Empty, "repository has no file")
 			emptyRepo++
 			continue
 		}
@@ -364,6 +394,7 @@ func GetRepos(project string, repos []Repo, parms ParamsReposDC, bitbucketURLBas
 
 		if len(branches) == 0 {
 			fmt.Printf("❗️ No branches found for repository %s\n", repo.Slug)
+			parms.Inventory.Add(project, repo.Slug, parms.Branch, inventory.NoBranch, "no branch found in the repository")
 			continue
 		}
 
@@ -385,7 +416,7 @@ func GetRepos(project string, repos []Repo, parms ParamsReposDC, bitbucketURLBas
 	result.NumRepositories = nbRepos
 	result.ProjectBranches = importantBranches
 
-	if err := saveAnalysisResult(result); err != nil {
+	if err := saveAnalysisResult(result, parms.ResultsDir); err != nil {
 		logAndExit(fmt.Sprintf("❌ Error creating Analysis file: %v\n", err), parms.Spin)
 	}
 
@@ -513,8 +544,8 @@ func findLargestBranch1(projectKey, repoSlug string, branches []Branch, parms Pa
 	return largestRepoSize, largestRepoBranch, nil
 }
 
-func saveAnalysisResult(result AnalysisResult) error {
-	file, err := os.Create("Results/config/analysis_repos_bitbucketdc.json")
+func saveAnalysisResult(result AnalysisResult, resultsDir string) error {
+	file, err := os.Create(filepath.Join(resultsDir, "config", "analysis_repos_bitbucketdc.json"))
 	if err != nil {
 		return err
 	}
@@ -575,6 +606,8 @@ func GetProjectBitbucketList(platformConfig map[string]interface{}, exclusionFil
 			Spin:             spin,
 			Branch:           platformConfig["Branch"].(string),
 			DefaultB:         platformConfig["DefaultBranch"].(bool),
+			ResultsDir:       platformConfig["ResultsDir"].(string),
+			Inventory:        inventory.FromConfig(platformConfig),
 		}
 		importantBranches, nbRepos, _ = GetReposProject(projects, parms, bitbucketURLBase, nbRepos, exclusionList)
 	} else {
@@ -589,6 +622,8 @@ func GetProjectBitbucketList(platformConfig map[string]interface{}, exclusionFil
 			Branch:           platformConfig["Branch"].(string),
 			Spin:             spin,
 			DefaultB:         platformConfig["DefaultBranch"].(bool),
+			ResultsDir:       platformConfig["ResultsDir"].(string),
+			Inventory:        inventory.FromConfig(platformConfig),
 		}
 		importantBranches, nbRepos, _ = GetRepos(platformConfig["Project"].(string), repos, parms, bitbucketURLBase, exclusionList)
 
@@ -618,37 +653,11 @@ func determineProjectsAndRepos(platformConfig map[string]interface{}, exclusionL
 
 	if project == "" && repo == "" {
 		spin.Start()
-		projects, err = fetchAllProjects(bitbucketURL, platformConfig["AccessToken"].(string), exclusionList)
+		projects, err = fetchAllProjects(bitbucketURL, platformConfig["AccessToken"].(string), exclusionList, inventory.FromConfig(platformConfig))
 		spin.Stop()
 	} else if project != "" && repo == "" {
 		if isProjectExcluded1(project, *exclusionList) {
-<<<<<<< HEAD
-			fmt.Println("\n❌ Projet", project, "is excluded from the analysis... edit <.cloc_bitbucketdc_ignore> file")
-			os.Exit(1)
-		} else {
-
-			spin.Start()
-			bitbucketURLProject := fmt.Sprintf("%s/%s", bitbucketURL, project)
-
-			projects, err := fetchOnelProjects(bitbucketURLProject, accessToken, exclusionList)
-			if err != nil {
-				fmt.Printf("\n❌ Error Get Project:%s - %v", project, err)
-				spin.Stop()
-				return nil, err
-			}
-			spin.Stop()
-
-			if len(projects) == 0 {
-				fmt.Printf("\n❌ Error Project:%s not exist - %v", project, err)
-				spin.Stop()
-				return nil, err
-			} else {
-				importantBranches, nbRepos, emptyRepo = GetReposProject(projects, url, baseapi, apiver, accessToken, bitbucketURLBase, branchmain, nbRepos, exclusionList, spin)
-
-			}
-=======
 			return nil, nil, fmt.Errorf("project %s is excluded from the analysis", project)
->>>>>>> ver1.0.3
 		}
 		spin.Start()
 		projects, err = fetchOnelProjects(fmt.Sprintf("%s/%s", bitbucketURL, project), platformConfig["AccessToken"].(string), exclusionList)
@@ -656,26 +665,7 @@ func determineProjectsAndRepos(platformConfig map[string]interface{}, exclusionL
 	} else if project != "" && repo != "" {
 		Texclude := project + "/" + repo
 		if isProjectAndRepoExcluded(Texclude, *exclusionList) {
-<<<<<<< HEAD
-			fmt.Println("\n❌ Projet ", project, "and the repository ", repo, "are excluded from the analysis...edit <.cloc_bitbucketdc_ignore> file")
-			os.Exit(1)
-		} else {
-
-			spin.Start()
-			bitbucketURLProject := fmt.Sprintf("%s/%s/repos/%s", bitbucketURL, project, repo)
-			Repos, err := fetchOneRepos(bitbucketURLProject, accessToken, exclusionList)
-			if err != nil {
-				fmt.Printf("\n❌ Error Get Repo:%s/%s - %v", project, repo, err)
-				spin.Stop()
-				return nil, err
-			}
-			fmt.Printf("Taille : %d", len(Repos))
-			spin.Stop()
-
-			importantBranches, nbRepos, emptyRepo = GetRepos(project, Repos, url, baseapi, apiver, accessToken, bitbucketURLBase, branchmain, exclusionList, spin)
-=======
 			return nil, nil, fmt.Errorf("project %s and repository %s are excluded from the analysis", project, repo)
->>>>>>> ver1.0.3
 		}
 		spin.Start()
 		repos, err = fetchOneRepos(fmt.Sprintf("%s/%s/repos/%s", bitbucketURL, project, repo), platformConfig["AccessToken"].(string), exclusionList)
@@ -754,7 +744,7 @@ func ifExistBranches(repoURL, accessToken string) ([]Branch, error) {
 	return branchesResp.Values, nil
 }
 
-func fetchAllProjects(url string, accessToken string, exclusionList *utils.ExclusionList) ([]Project, error) {
+func fetchAllProjects(url string, accessToken string, exclusionList *utils.ExclusionList, inv *inventory.Log) ([]Project, error) {
 	var allProjects []Project
 	for {
 		projectsResp, err := fetchProjects(url, accessToken, true)
@@ -770,6 +760,8 @@ func fetchAllProjects(url string, accessToken string, exclusionList *utils.Exclu
 			} else {
 				if !isProjectExcluded(exclusionList, project.Key) {
 					allProjects = append(allProjects, project)
+				} else {
+					inv.Add(project.Key, "", "", inventory.Excluded, "project is in the exclusion file")
 				}
 			}
 		}
@@ -889,7 +881,7 @@ func isRepoExcluded(exclusionList *utils.ExclusionList, repo string) bool {
 	return excluded
 }
 
-func fetchAllRepos(url string, accessToken string, exclusionList *utils.ExclusionList) ([]Repo, error) {
+func fetchAllRepos(url string, accessToken string, exclusionList *utils.ExclusionList, inv *inventory.Log) ([]Repo, error) {
 	var allRepos []Repo
 	for {
 		reposResp, err := fetchRepos(url, accessToken, true)
@@ -905,6 +897,8 @@ func fetchAllRepos(url string, accessToken string, exclusionList *utils.Exclusio
 			} else {
 				if !isRepoExcluded(exclusionList, KEYTEST) {
 					allRepos = append(allRepos, repo)
+				} else {
+					inv.Add(repo.Project.Key, repo.Slug, "", inventory.Excluded, "repository is in the exclusion file")
 				}
 			}
 
@@ -934,6 +928,10 @@ func fetchRepos(url string, accessToken string, isProjectResponse bool) (interfa
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode != http.StatusOK {
+		return nil, &statusError{URL: url, StatusCode: resp.StatusCode}
+	}
+
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, err
@@ -8,12 +8,15 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"path/filepath"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/briandowns/spinner"
 	"github.com/colussim/GoLC/assets"
+	"github.com/colussim/GoLC/pkg/devops/inventory"
+	"github.com/colussim/GoLC/pkg/report"
 	"github.com/google/go-github/v62/github"
 )
 
@@ -52,6 +55,9 @@ type ParamsReposGithub struct {
 	Period        int
 	Stats         bool
 	DefaultB      bool
+	ResultsDir    string
+	Inventory     *inventory.Log
+	Version       string // Version of GoLC, written in the metadata of the results
 }
 type Repository struct {
 	ID            int    `json:"id"`
@@ -129,7 +135,7 @@ type LanguageInfo1 struct {
 const PrefixMsg = "Get Repo(s)..."
 const MessageApiRate = "❗️ Rate limit exceeded. Waiting for rate limit reset..."
 const ApiHeader1 = "application/vnd.github.v3+json"
-const ErrorMesssage1 = "❌ Error saving repositories in file Results/config/analysis_repos_github.json: %v\n"
+const ErrorMesssage1 = "❌ Error saving repositories in file %s/config/analysis_repos_github.json: %v\n"
 
 // Load repository ignore map from file
 func loadExclusionRepos1(filename string) (ExclusionRepos, error) {
@@ -162,9 +168,10 @@ func shouldIgnore(repoName string, ignoreMap ExclusionRepos) bool {
 	return ignored
 }
 
-func SaveResult(result AnalysisResult) error {
+func SaveResult(result AnalysisResult, resultsDir string) error {
 	// Open or create the file
-	file, err := os.Create("Results/config/analysis_result_github.json")
+	filePath := filepath.Join(resultsDir, "config", "analysis_result_github.json")
+	file, err := os.Create(filePath)
 	if err != nil {
 		fmt.Println("❌ Error creating Analysis file:", err)
 		return err
@@ -176,7 +183,7 @@ func SaveResult(result AnalysisResult) error {
 
 	// Encode the result and write it to the file
 	if err := encoder.Encode(result); err != nil {
-		fmt.Println("❌ Error encoding JSON file <Results/config/analysis_result_github.json> :", err)
+		fmt.Printf("❌ Error encoding JSON file <%s> : %v\n", filePath, err)
 		return err
 	}
 
@@ -184,9 +191,10 @@ func SaveResult(result AnalysisResult) error {
 	return nil
 }
 
-func SaveBranch(branch RepoBranch) error {
+func SaveBranch(branch RepoBranch, resultsDir string) error {
 	// Open or create the file
-	file, err := os.Create("Results/config/analysis_branch_github.json")
+	filePath := filepath.Join(resultsDir, "config", "analysis_branch_github.json")
+	file, err := os.Create(filePath)
 	if err != nil {
 		fmt.Println("❌ Error creating Analysis Branch file:", err)
 		return err
@@ -198,7 +206,7 @@ func SaveBranch(branch RepoBranch) error {
 
 	// Encode the Branch and write it to the file
 	if err := encoder.Encode(branch); err != nil {
-		fmt.Println("❌ Error encoding JSON file <Results/config/analysis_branch_github.json> :", err)
+		fmt.Printf("❌ Error encoding JSON file <%s> : %v\n", filePath, err)
 		return err
 	}
 
@@ -206,9 +214,10 @@ func SaveBranch(branch RepoBranch) error {
 	return nil
 }
 
-func SaveCommit(repos []*github.RepositoryCommit) error {
+func SaveCommit(repos []*github.RepositoryCommit, resultsDir string) error {
 	// Open or create the file
-	file, err := os.Create("Results/config/analysis_commit_github.json")
+	filePath := filepath.Join(resultsDir, "config", "analysis_commit_github.json")
+	file, err := os.Create(filePath)
 	if err != nil {
 		fmt.Println("❌ Error creating Analysis Repos file:", err)
 		return err
@@ -220,16 +229,17 @@ func SaveCommit(repos []*github.RepositoryCommit) error {
 
 	// Encode the Branch and write it to the file
 	if err := encoder.Encode(repos); err != nil {
-		fmt.Println("❌ Error encoding JSON file <Results/config/analysis_commit_github.json> :", err)
+		fmt.Printf("❌ Error encoding JSON file <%s> : %v\n", filePath, err)
 		return err
 	}
 
 	//fmt.Println("✅ Commits saved successfully!")
 	return nil
 }
-func SaveRepos(repos []*github.Repository) error {
+func SaveRepos(repos []*github.Repository, resultsDir string) error {
 	// Open or create the file
-	file, err := os.Create("Results/config/analysis_repos_github.json")
+	filePath := filepath.Join(resultsDir, "config", "analysis_repos_github.json")
+	file, err := os.Create(filePath)
 	if err != nil {
 		fmt.Println("❌ Error creating Analysis Repos file:", err)
 		return err
@@ -241,7 +251,7 @@ func SaveRepos(repos []*github.Repository) error {
 
 	// Encode the Branch and write it to the file
 	if err := encoder.Encode(repos); err != nil {
-		fmt.Println("❌ Error encoding JSON file <Results/config/analysis_repos_github.json> :", err)
+		fmt.Printf("❌ Error encoding JSON file <%s> : %v\n", filePath, err)
 		return err
 	}
 
@@ -249,9 +259,10 @@ func SaveRepos(repos []*github.Repository) error {
 	return nil
 }
 
-func SaveLast(last Lastanalyse) error {
+func SaveLast(last Lastanalyse, resultsDir string) error {
 	// Open or create the file
-	file, err := os.Create("Results/config/analysis_last_github.json")
+	filePath := filepath.Join(resultsDir, "config", "analysis_last_github.json")
+	file, err := os.Create(filePath)
 	if err != nil {
 		fmt.Println("❌ Error creating Analysis Last file:", err)
 		return err
@@ -263,7 +274,7 @@ func SaveLast(last Lastanalyse) error {
 
 	// Encode the Branch and write it to the file
 	if err := encoder.Encode(last); err != nil {
-		fmt.Println("❌ Error encoding JSON file <Results/config/analysis_last_github.json> :", err)
+		fmt.Printf("❌ Error encoding JSON file <%s> : %v\n", filePath, err)
 		return err
 	}
 
@@ -285,17 +296,20 @@ func GetReposGithub(parms ParamsReposGithub, ctx context.Context, client *github
 	for _, repo := range parms.Repos {
 		repoName := *repo.Name
 		if repo.GetArchived() {
+			parms.Inventory.Add(parms.Organization, repoName, "", inventory.Archived, "repository is archived")
 			cptarchiv++
 			continue
 		}
 		if len(parms.ExclusionList) != 0 && shouldIgnore(repoName, parms.ExclusionList) {
 			fmt.Printf("\t   ✅ Skipping analysis for repository '%s' as per ignore list.\n", repoName)
+			parms.Inventory.Add(parms.Organization, repoName, "", inventory.Excluded, "repository is in the exclusion file")
 			notAnalyzedCount++
 			continue
 		}
 		isEmpty, err := reposIfEmpty(ctx, client, repoName, parms.Organization)
 		if err != nil {
 			fmt.Print(err.Error())
+			parms.Inventory.Add(parms.Organization, repoName, "", apiErrorStatus(err), strings.TrimSpace(err.Error()))
 			continue
 		}
 		if !isEmpty {
@@ -308,6 +322,7 @@ func GetReposGithub(parms ParamsReposGithub, ctx context.Context, client *github
 			})
 			TotalBranches += len(repoBranches)
 		} else {
+			parms.Inventory.Add(parms.Organization, repoName, "", inventory.Empty, "repository has no commit")
 			emptyRepo++
 		}
 		cpt++
@@ -317,7 +332,7 @@ func GetReposGithub(parms ParamsReposGithub, ctx context.Context, client *github
 		NumRepositories: parms.NBRepos,
 		ProjectBranches: importantBranches,
 	}
-	if err := SaveResult(result); err != nil {
+	if err := SaveResult(result, parms.ResultsDir); err != nil {
 		fmt.Println("❌ Error Save Result of Analysis :", err)
 		os.Exit(1)
 	}
@@ -601,8 +616,8 @@ func GetRepoGithubList(platformConfig map[string]interface{}, exclusionfile stri
 	params := getCommonParams(platformConfig, repositories, exclusionList, spin)
 	sortRepositoriesByUpdatedAt(repositories)
 
-	if err := SaveRepos(repositories); err != nil {
-		fmt.Printf(ErrorMesssage1, err)
+	if err := SaveRepos(repositories, params.ResultsDir); err != nil {
+		fmt.Printf(ErrorMesssage1, params.ResultsDir, err)
 	}
 
 	importantBranches, emptyRepo, nbRepos, TotalBranches, totalExclude, totalArchiv = GetReposGithub(params, ctx, client)
@@ -693,6 +708,8 @@ func getCommonParams(platformConfig map[string]interface{}, repositories []*gith
 		Period:        int(platformConfig["Period"].(float64)),
 		Stats:         platformConfig["Stats"].(bool),
 		DefaultB:      platformConfig["DefaultBranch"].(bool),
+		ResultsDir:    platformConfig["ResultsDir"].(string),
+		Inventory:     inventory.FromConfig(platformConfig),
 	}
 }
 
@@ -789,14 +806,16 @@ func FastAnalys(platformConfig map[string]interface{}, exlusionfile string) erro
 			Branch:        platformConfig["Branch"].(string),
 			Period:        int(platformConfig["Period"].(float64)),
 			Stats:         platformConfig["Stats"].(bool),
+			ResultsDir:    platformConfig["ResultsDir"].(string),
+			Version:       platformConfig["Version"].(string),
 		}
 
 		sortRepositoriesByUpdatedAt(repositories)
 
 		// Save List of Repos
-		err := SaveRepos(repositories)
+		err := SaveRepos(repositories, parms.ResultsDir)
 		if err != nil {
-			fmt.Printf(ErrorMesssage1, err)
+			fmt.Printf(ErrorMesssage1, parms.ResultsDir, err)
 		}
 
 		nbRepos, emptyRepo, totalExclude, totalArchiv, err = GetGithubLanguages(parms, ctx, client, int(platformConfig["Factor"].(float64)))
@@ -830,6 +849,8 @@ func FastAnalys(platformConfig map[string]interface{}, exlusionfile string) erro
 			Branch:        platformConfig["Branch"].(string),
 			Period:        int(platformConfig["Period"].(float64)),
 			Stats:         platformConfig["Stats"].(bool),
+			ResultsDir:    platformConfig["ResultsDir"].(string),
+			Version:       platformConfig["Version"].(string),
 		}
 		nbRepos, emptyRepo, totalExclude, totalArchiv, err = GetGithubLanguages(parms, ctx, client, int(platformConfig["Factor"].(float64)))
 		if err != nil {
@@ -914,6 +935,16 @@ func GetGithubLanguages(parms ParamsReposGithub, ctx context.Context, client *gi
 			}
 
 			output := map[string]interface{}{
+				// The languages of the GitHub API are not counted at a commit
+				"Metadata": report.ResultMetadata{
+					Platform:     "github",
+					Organization: parms.Organization,
+					Project:      parms.Organization,
+					Repository:   repoName,
+					Branch:       repo.GetDefaultBranch(),
+					ScanTime:     time.Now().UTC(),
+					Version:      parms.Version,
+				},
 				"TotalFiles":      totalFiles,
 				"TotalLines":      totalLines,
 				"TotalBlankLines": totalBlankLines,
@@ -930,7 +961,7 @@ func GetGithubLanguages(parms ParamsReposGithub, ctx context.Context, client *gi
 			}
 
 			// Write JSON data to file
-			Resultfile := fmt.Sprintf("Results/Result_%s_%s.json", parms.Organization, repoName)
+			Resultfile := filepath.Join(parms.ResultsDir, fmt.Sprintf("Result_%s_%s.json", parms.Organization, repoName))
 			file, err := os.Create(Resultfile)
 			if err != nil {
 				mess := fmt.Sprintf("\r❌ Error creating file: %v\n", err)
@@ -954,6 +985,15 @@ func GetGithubLanguages(parms ParamsReposGithub, ctx context.Context, client *gi
 	return parms.NBRepos, emptyRepo, notAnalyzedCount, cptarchiv, nil
 }
 
+// Return the inventory status of a GitHub API error
+func apiErrorStatus(err error) string {
+	var githubError *github.ErrorResponse
+	if errors.As(err, &githubError) && githubError.Response != nil {
+		return inventory.ErrorStatus(githubError.Response.StatusCode)
+	}
+	return inventory.Error
+}
+
 func reposIfEmpty(ctx context.Context, client *github.Client, repoName, org string) (bool, error) {
 	// Get the number of commits in the repository
 	commits, _, err := client.Repositories.ListCommits(ctx, org, repoName, nil)
@@ -970,10 +1010,10 @@ func reposIfEmpty(ctx context.Context, client *github.Client, repoName, org stri
 			if githubError.Message == "Git Repository is empty." {
 				return true, nil
 			} else {
-				return true, fmt.Errorf("\n❌ Failed to check repository <%s> is empty - : %v", repoName, err)
+				return true, fmt.Errorf("\n❌ Failed to check repository <%s> is empty - : %w", repoName, err)
 			}
 		} else {
-			return true, fmt.Errorf("\n❌ Failed to check repository <%s> is empty - : %v", repoName, err)
+			return true, fmt.Errorf("\n❌ Failed to check repository <%s> is empty - : %w", repoName, err)
 		}
 	}
 
@@ -6,10 +6,13 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"path"
+	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/briandowns/spinner"
+	"github.com/colussim/GoLC/pkg/devops/inventory"
 	"github.com/xanzy/go-gitlab"
 )
 
@@ -36,6 +39,7 @@ type AnalyzeProject struct {
 	ExclusionList ExclusionRepos
 	Spin1         *spinner.Spinner
 	Org           string
+	Inventory     *inventory.Log
 }
 
 // RepositoryMap represents a map of repositories to ignore
@@ -267,9 +271,10 @@ func isExcluded(projectName string, exclusionList map[string]bool) bool {
 
 }
 
-func SaveResult(result AnalysisResult) error {
+func SaveResult(result AnalysisResult, resultsDir string) error {
 	// Open or create the file
-	file, err := os.Create("Results/config/analysis_result_github.json")
+	filePath := filepath.Join(resultsDir, "config", "analysis_result_github.json")
+	file, err := os.Create(filePath)
 	if err != nil {
 		fmt.Println("❌ Error creating Analysis file:", err)
 		return err
@@ -281,7 +286,7 @@ func SaveResult(result AnalysisResult) error {
 
 	// Encode the result and write it to the file
 	if err := encoder.Encode(result); err != nil {
-		fmt.Println("❌ Error encoding JSON file <Results/config/analysis_result_github.json> :", err)
+		fmt.Printf("❌ Error encoding JSON file <%s> : %v\n", filePath, err)
 		return err
 	}
 
@@ -293,16 +298,20 @@ func analyzeProj(analyzeProject AnalyzeProject) (ProjectBranch, int, int, int) {
 
 	largestSize := 0
 
-	if isExcluded(analyzeProject.Project.PathWithNamespace, analyzeProject.ExclusionList) {
+	project := analyzeProject.Project
+	if isExcluded(project.PathWithNamespace, analyzeProject.ExclusionList) {
+		analyzeProject.Inventory.Add(path.Dir(project.PathWithNamespace), project.Name, "", inventory.Excluded, "project is in the exclusion file")
 		return ProjectBranch{}, 1, 0, 0
 	}
 
 	// Check if the project is empty or archived
-	if analyzeProject.Project.EmptyRepo || analyzeProject.Project.Archived {
-		if analyzeProject.Project.EmptyRepo {
+	if project.EmptyRepo || project.Archived {
+		if project.EmptyRepo {
+			analyzeProject.Inventory.Add(path.Dir(project.PathWithNamespace), project.Name, "", inventory.Empty, "project repository is empty")
 			return ProjectBranch{}, 0, 1, 0
 		}
-		if analyzeProject.Project.Archived {
+		if project.Archived {
+			analyzeProject.Inventory.Add(path.Dir(project.PathWithNamespace), project.Name, "", inventory.Archived, "project is archived")
 			return ProjectBranch{}, 0, 0, 1
 		}
 	}
@@ -349,17 +358,20 @@ func processProject(analyzeProject AnalyzeProject, cpt int, spin1 *spinner.Spinn
 	return projectBranches, cpt
 }
 
-func isProjectExcludedOrInvalid(project *gitlab.Project, exclusionList ExclusionRepos, emptyRepos, archivedRepos *int) (bool, bool, bool) {
+func isProjectExcludedOrInvalid(project *gitlab.Project, exclusionList ExclusionRepos, emptyRepos, archivedRepos *int, inv *inventory.Log) (bool, bool, bool) {
 	if isExcluded(project.PathWithNamespace, exclusionList) {
+		inv.Add(path.Dir(project.PathWithNamespace), project.Name, "", inventory.Excluded, "project is in the exclusion file")
 		return true, false, false
 	}
 
 	if project.EmptyRepo {
+		inv.Add(path.Dir(project.PathWithNamespace), project.Name, "", inventory.Empty, "project repository is empty")
 		*emptyRepos++
 		return false, true, false
 	}
 
 	if project.Archived {
+		inv.Add(path.Dir(project.PathWithNamespace), project.Name, "", inventory.Archived, "project is archived")
 		*archivedRepos++
 		return false, false, true
 	}
@@ -386,6 +398,7 @@ func GetRepoGitLabList(platformConfig map[string]interface{}, exclusionfile stri
 
 	excludedProjects := 0
 	result := AnalysisResult{}
+	inv := inventory.FromConfig(platformConfig)
 
 	// Calculating the period
 	until := time.Now()
@@ -448,6 +461,7 @@ func GetRepoGitLabList(platformConfig map[string]interface{}, exclusionfile stri
 					ExclusionList: exclusionList,
 					Spin1:         spin1,
 					Org:           platformConfig["Organization"].(string),
+					Inventory:     inv,
 				}
 
 				projectBranches, cpt = processProject(parmsproject, cpt, spin1, projectBranches, &emptyRepos, &archivedRepos, &excludedProjects)
@@ -476,6 +490,7 @@ func GetRepoGitLabList(platformConfig map[string]interface{}, exclusionfile stri
 				ExclusionList: exclusionList,
 				Spin1:         spin1,
 				Org:           platformConfig["Organization"].(string),
+				Inventory:     inv,
 			}
 
 			projectBranches, _ = processProject(parmsproject, cpt, spin1, projectBranches, &emptyRepos, &archivedRepos, &excludedProjects)
@@ -514,7 +529,7 @@ func GetRepoGitLabList(platformConfig map[string]interface{}, exclusionfile stri
 				//	branches := make([]*gitlab.Branch, 0)
 				TotalRepoBranches := 0
 
-				excluded, empty, archived := isProjectExcludedOrInvalid(project, exclusionList, &emptyRepos, &archivedRepos)
+				excluded, empty, archived := isProjectExcludedOrInvalid(project, exclusionList, &emptyRepos, &archivedRepos, inv)
 				if excluded {
 					excludedProjects++
 					continue
@@ -566,7 +581,7 @@ func GetRepoGitLabList(platformConfig map[string]interface{}, exclusionfile stri
 				log.Fatalf(MessageError2, platformConfig["Project"].(string), err)
 			}
 
-			excluded, empty, archived := isProjectExcludedOrInvalid(project, exclusionList, &emptyRepos, &archivedRepos)
+			excluded, empty, archived := isProjectExcludedOrInvalid(project, exclusionList, &emptyRepos, &archivedRepos, inv)
 			if excluded || empty || archived {
 				log.Fatalf(MessageError6, platformConfig["Project"].(string))
 
@@ -656,7 +671,7 @@ func GetRepoGitLabList(platformConfig map[string]interface{}, exclusionfile stri
 			for _, project := range projects {
 				//largestSize := 0
 
-				excluded, empty, archived := isProjectExcludedOrInvalid(project, exclusionList, &emptyRepos, &archivedRepos)
+				excluded, empty, archived := isProjectExcludedOrInvalid(project, exclusionList, &emptyRepos, &archivedRepos, inv)
 				if excluded {
 					excludedProjects++
 					continue
@@ -670,6 +685,7 @@ func GetRepoGitLabList(platformConfig map[string]interface{}, exclusionfile stri
 
 				largestBranch := platformConfig["Branch"].(string)
 				if !branchExists(gitlabClient, project.ID, largestBranch) {
+					inv.Add(path.Dir(project.PathWithNamespace), project.Name, largestBranch, inventory.NoBranch, "branch does not exist in the project")
 					spin1.Stop()
 					continue
 				}
@@ -709,7 +725,7 @@ func GetRepoGitLabList(platformConfig map[string]interface{}, exclusionfile stri
 	result.NumRepositories = len(projectBranches)
 	result.ProjectBranches = projectBranches
 	// Save Result of Analysis
-	err = SaveResult(result)
+	err = SaveResult(result, platformConfig["ResultsDir"].(string))
 	if err != nil {
 		fmt.Println("❌ Error Save Result of Analysis :", err)
 		os.Exit(1)
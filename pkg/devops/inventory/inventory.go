@@ -0,0 +1,110 @@
+package inventory
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// Status of a repository in the decision log
+const (
+	Selected     = "selected"
+	Archived     = "archived"
+	Empty        = "empty"
+	Excluded     = "excluded"
+	NoPermission = "no-permission"
+	NoBranch     = "no-branch"
+	Error        = "error"
+)
+
+// Key of the decision log in the platform configuration
+const ConfigKey = "Inventory"
+
+type Decision struct {
+	Project    string `json:"Project"`
+	Repository string `json:"Repository"`
+	Branch     string `json:"Branch"`
+	Status     string `json:"Status"`
+	Reason     string `json:"Reason"`
+}
+
+// Log records why each repository of a platform is analysed or skipped.
+// A nil *Log discards every decision, so the connectors can always call Add.
+type Log struct {
+	mu        sync.Mutex
+	Decisions []Decision
+}
+
+func NewLog() *Log {
+	return &Log{}
+}
+
+// Return the decision log of the platform configuration, or nil when the inventory is not enabled
+func FromConfig(platformConfig map[string]interface{}) *Log {
+	l, _ := platformConfig[ConfigKey].(*Log)
+	return l
+}
+
+func (l *Log) Add(project, repository, branch, status, reason string) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.Decisions = append(l.Decisions, Decision{
+		Project:    project,
+		Repository: repository,
+		Branch:     branch,
+		Status:     status,
+		Reason:     reason,
+	})
+}
+
+// Return the status of an API error from its HTTP status code
+func ErrorStatus(statusCode int) string {
+	if statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden {
+		return NoPermission
+	}
+	return Error
+}
+
+// Copy of the decisions, the connectors may still add some
+func (l *Log) decisions() []Decision {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]Decision(nil), l.Decisions...)
+}
+
+// Number of decisions by status
+func (l *Log) Counts() map[string]int {
+	counts := make(map[string]int)
+	for _, d := range l.decisions() {
+		counts[d.Status]++
+	}
+	return counts
+}
+
+func (l *Log) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(l.decisions())
+}
+
+func (l *Log) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"Project", "Repository", "Branch", "Status", "Reason"}); err != nil {
+		return err
+	}
+	for _, d := range l.decisions() {
+		if err := writer.Write([]string{d.Project, d.Repository, d.Branch, d.Status, d.Reason}); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,95 @@
+package inventory
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+func TestLogConcurrent(t *testing.T) {
+	l := NewLog()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			status := Selected
+			if i%5 == 0 {
+				status = Archived
+			}
+			l.Add("PRJ", fmt.Sprintf("repo%d", i), "main", status, "")
+			l.Counts()
+			l.WriteJSON(&bytes.Buffer{})
+			l.WriteCSV(&bytes.Buffer{})
+		}(i)
+	}
+	wg.Wait()
+
+	counts := l.Counts()
+	if counts[Selected] != 40 || counts[Archived] != 10 {
+		t.Errorf("counts %v, want 40 selected and 10 archived", counts)
+	}
+}
+
+func TestLogWrite(t *testing.T) {
+	l := NewLog()
+	l.Add("PRJ", "api", "main", Selected, "largest branch")
+	l.Add("PRJ", "old", "", Archived, "archived repository")
+
+	var buf bytes.Buffer
+	if err := l.WriteJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var decisions []Decision
+	if err := json.Unmarshal(buf.Bytes(), &decisions); err != nil {
+		t.Fatal(err)
+	}
+	if len(decisions) != 2 || decisions[1] != (Decision{Project: "PRJ", Repository: "old", Status: Archived, Reason: "archived repository"}) {
+		t.Errorf("json decisions %+v", decisions)
+	}
+
+	buf.Reset()
+	if err := l.WriteCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 3 || records[0][0] != "Project" || records[1][1] != "api" || records[2][3] != Archived {
+		t.Errorf("csv records %v", records)
+	}
+}
+
+func TestNilLog(t *testing.T) {
+	var l *Log
+	l.Add("PRJ", "api", "main", Selected, "")
+	if counts := l.Counts(); len(counts) != 0 {
+		t.Errorf("counts of a nil log %v, want none", counts)
+	}
+	if FromConfig(map[string]interface{}{}) != nil {
+		t.Error("FromConfig returns a log when the inventory is not enabled")
+	}
+}
+
+func TestErrorStatus(t *testing.T) {
+	tests := []struct {
+		code int
+		want string
+	}{
+		{http.StatusUnauthorized, NoPermission},
+		{http.StatusForbidden, NoPermission},
+		{http.StatusNotFound, Error},
+		{http.StatusInternalServerError, Error},
+	}
+	for _, tt := range tests {
+		if got := ErrorStatus(tt.code); got != tt.want {
+			t.Errorf("ErrorStatus(%d) = %s, want %s", tt.code, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package diff
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Status of a result between two runs
+const (
+	Added     = "added"
+	Removed   = "removed"
+	Changed   = "changed"
+	Unchanged = "unchanged"
+)
+
+type resultFile struct {
+	// Written by the scans since the metadata of the results, nil before
+	Metadata *struct {
+		Project    string `json:"Project"`
+		Repository string `json:"Repository"`
+	} `json:"Metadata"`
+	TotalCodeLines int `json:"TotalCodeLines"`
+	Results        []struct {
+		Language  string `json:"Language"`
+		CodeLines int    `json:"CodeLines"`
+	} `json:"Results"`
+}
+
+// Code lines of a repository, language or file in the old and the new results
+type Change struct {
+	Name     string
+	Status   string
+	OldLines int
+	NewLines int
+	Delta    int
+}
+
+// Code lines of a repository in a run, in total and by language
+type Result struct {
+	TotalCodeLines int
+	Languages      map[string]int
+}
+
+type RepoDiff struct {
+	Change
+	Languages []Change
+}
+
+// Code lines of a file in two revisions of a repository
+type FileDiff struct {
+	Change
+	Language string
+}
+
+type Report struct {
+	Repos     []RepoDiff
+	Languages []Change   // Code lines by language of all the repositories
+	Files     []FileDiff // Only for the revisions of a repository
+	OldTotal  int
+	NewTotal  int
+	Delta     int
+}
+
+// Read a Result_*.json file
+func loadResult(path string) (resultFile, error) {
+	var result resultFile
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return result, err
+	}
+	err = json.Unmarshal(data, &result)
+	return result, err
+}
+
+// Name of the repository of a Result_*.json file
+func resultName(path string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(filepath.Base(path), "Result_"), ".json")
+}
+
+// Name of the repository of a result, project/repository with its metadata, or else from the file name
+func (r resultFile) name(path string) string {
+	switch {
+	case r.Metadata == nil || r.Metadata.Repository == "":
+		return resultName(path)
+	case r.Metadata.Project == "":
+		return r.Metadata.Repository
+	default:
+		return r.Metadata.Project + "/" + r.Metadata.Repository
+	}
+}
+
+// Code lines of a result in total and by language
+func (r resultFile) result() Result {
+	languages := make(map[string]int)
+	for _, lang := range r.Results {
+		languages[lang.Language] += lang.CodeLines
+	}
+	return Result{TotalCodeLines: r.TotalCodeLines, Languages: languages}
+}
+
+// Compare the code lines of two sets of names, sorted by name
+func compare(oldLines, newLines map[string]int) []Change {
+	var changes []Change
+
+	for name, old := range oldLines {
+		lines, ok := newLines[name]
+		change := Change{Name: name, OldLines: old, NewLines: lines}
+		switch {
+		case !ok:
+			change.Status = Removed
+		case lines != old:
+			change.Status = Changed
+		default:
+			change.Status = Unchanged
+		}
+		change.Delta = change.NewLines - change.OldLines
+		changes = append(changes, change)
+	}
+
+	for name, lines := range newLines {
+		if _, ok := oldLines[name]; !ok {
+			changes = append(changes, Change{Name: name, Status: Added, NewLines: lines, Delta: lines})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Name < changes[j].Name
+	})
+	return changes
+}
+
+// Build the report of two sets of results by repository name
+func CompareResults(oldResults, newResults map[string]Result) *Report {
+	report := &Report{}
+
+	oldLines, newLines := make(map[string]int), make(map[string]int)
+	oldLanguages, newLanguages := make(map[string]int), make(map[string]int)
+	for name, result := range oldResults {
+		oldLines[name] = result.TotalCodeLines
+		for lang, lines := range result.Languages {
+			oldLanguages[lang] += lines
+		}
+	}
+	for name, result := range newResults {
+		newLines[name] = result.TotalCodeLines
+		for lang, lines := range result.Languages {
+			newLanguages[lang] += lines
+		}
+	}
+
+	for _, change := range compare(oldLines, newLines) {
+		repo := RepoDiff{Change: change}
+		repo.Languages = compare(oldResults[change.Name].Languages, newResults[change.Name].Languages)
+		report.Repos = append(report.Repos, repo)
+		report.OldTotal += change.OldLines
+		report.NewTotal += change.NewLines
+	}
+	report.Languages = compare(oldLanguages, newLanguages)
+	report.Delta = report.NewTotal - report.OldTotal
+
+	return report
+}
+
+// Compare two Result_*.json files of a repository, it is named after the new file
+func CompareFiles(oldFile, newFile string) (*Report, error) {
+	oldResult, err := loadResult(oldFile)
+	if err != nil {
+		return nil, err
+	}
+	newResult, err := loadResult(newFile)
+	if err != nil {
+		return nil, err
+	}
+
+	name := newResult.name(newFile)
+	return CompareResults(map[string]Result{name: oldResult.result()}, map[string]Result{name: newResult.result()}), nil
+}
@@ -0,0 +1,143 @@
+package diff
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name     string
+		oldLines map[string]int
+		newLines map[string]int
+		want     []Change
+	}{
+		{
+			name:     "empty",
+			oldLines: nil,
+			newLines: nil,
+			want:     nil,
+		},
+		{
+			name:     "all statuses",
+			oldLines: map[string]int{"a": 10, "b": 20, "c": 30},
+			newLines: map[string]int{"b": 25, "c": 30, "d": 5},
+			want: []Change{
+				{Name: "a", Status: Removed, OldLines: 10, Delta: -10},
+				{Name: "b", Status: Changed, OldLines: 20, NewLines: 25, Delta: 5},
+				{Name: "c", Status: Unchanged, OldLines: 30, NewLines: 30},
+				{Name: "d", Status: Added, NewLines: 5, Delta: 5},
+			},
+		},
+		{
+			name:     "zero lines are kept",
+			oldLines: map[string]int{"a": 0},
+			newLines: map[string]int{"a": 0, "b": 0},
+			want: []Change{
+				{Name: "a", Status: Unchanged},
+				{Name: "b", Status: Added},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := compare(tt.oldLines, tt.newLines); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("compare() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareResults(t *testing.T) {
+	oldResults := map[string]Result{
+		"PRJ/api": {TotalCodeLines: 120, Languages: map[string]int{"Go": 100, "Shell": 20}},
+		"PRJ/web": {TotalCodeLines: 50, Languages: map[string]int{"JavaScript": 50}},
+	}
+	newResults := map[string]Result{
+		"PRJ/api":   {TotalCodeLines: 150, Languages: map[string]int{"Go": 150}},
+		"PRJ/tools": {TotalCodeLines: 30, Languages: map[string]int{"Go": 30}},
+	}
+
+	report := CompareResults(oldResults, newResults)
+
+	if report.OldTotal != 170 || report.NewTotal != 180 || report.Delta != 10 {
+		t.Errorf("totals %d -> %d (%d), want 170 -> 180 (10)", report.OldTotal, report.NewTotal, report.Delta)
+	}
+
+	wantRepos := []RepoDiff{
+		{
+			Change: Change{Name: "PRJ/api", Status: Changed, OldLines: 120, NewLines: 150, Delta: 30},
+			Languages: []Change{
+				{Name: "Go", Status: Changed, OldLines: 100, NewLines: 150, Delta: 50},
+				{Name: "Shell", Status: Removed, OldLines: 20, Delta: -20},
+			},
+		},
+		{
+			Change:    Change{Name: "PRJ/tools", Status: Added, NewLines: 30, Delta: 30},
+			Languages: []Change{{Name: "Go", Status: Added, NewLines: 30, Delta: 30}},
+		},
+		{
+			Change:    Change{Name: "PRJ/web", Status: Removed, OldLines: 50, Delta: -50},
+			Languages: []Change{{Name: "JavaScript", Status: Removed, OldLines: 50, Delta: -50}},
+		},
+	}
+	if !reflect.DeepEqual(report.Repos, wantRepos) {
+		t.Errorf("repositories %+v, want %+v", report.Repos, wantRepos)
+	}
+
+	wantLanguages := []Change{
+		{Name: "Go", Status: Changed, OldLines: 100, NewLines: 180, Delta: 80},
+		{Name: "JavaScript", Status: Removed, OldLines: 50, Delta: -50},
+		{Name: "Shell", Status: Removed, OldLines: 20, Delta: -20},
+	}
+	if !reflect.DeepEqual(report.Languages, wantLanguages) {
+		t.Errorf("languages %+v, want %+v", report.Languages, wantLanguages)
+	}
+}
+
+func TestCompareFiles(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	old := write("old.json", `{"TotalCodeLines": 10, "Results": [{"Language": "Go", "CodeLines": 10}]}`)
+
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    string
+	}{
+		{"file name", "Result_PRJ_api_main.json", `{"TotalCodeLines": 12, "Results": [{"Language": "Go", "CodeLines": 12}]}`, "PRJ_api_main"},
+		{"metadata", "Result_PRJ_api_1a2b3c4d.json", `{"Metadata": {"Project": "PRJ", "Repository": "api"}, "TotalCodeLines": 12, "Results": [{"Language": "Go", "CodeLines": 12}]}`, "PRJ/api"},
+		{"metadata without project", "Result_api.json", `{"Metadata": {"Project": "", "Repository": "api"}, "TotalCodeLines": 12, "Results": [{"Language": "Go", "CodeLines": 12}]}`, "api"},
+		{"metadata without repository", "Result_other.json", `{"Metadata": {"Project": "PRJ"}, "TotalCodeLines": 12, "Results": [{"Language": "Go", "CodeLines": 12}]}`, "other"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := CompareFiles(old, write(tt.file, tt.content))
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := []RepoDiff{{
+				Change:    Change{Name: tt.want, Status: Changed, OldLines: 10, NewLines: 12, Delta: 2},
+				Languages: []Change{{Name: "Go", Status: Changed, OldLines: 10, NewLines: 12, Delta: 2}},
+			}}
+			if !reflect.DeepEqual(report.Repos, want) {
+				t.Errorf("repositories %+v, want %+v", report.Repos, want)
+			}
+		})
+	}
+
+	if _, err := CompareFiles(old, filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("CompareFiles() of a missing file succeeded")
+	}
+}
@@ -0,0 +1,107 @@
+package diff
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/colussim/GoLC/pkg/gogit"
+	"github.com/colussim/GoLC/pkg/goloc"
+	"github.com/colussim/GoLC/pkg/goloc/language"
+)
+
+// Code lines and language of a file
+type fileLines struct {
+	language  string
+	codeLines int
+}
+
+// Compare the code lines of two revisions of a git repository, local or remote, by file and language.
+// The revisions are branches, tags or commits, scanned like a repository of a DevOps platform.
+func CompareRefs(ctx context.Context, src, oldRef, newRef string, languages language.Languages) (*Report, error) {
+	repo, err := gogit.OpenContext(ctx, src)
+	if err != nil {
+		return nil, err
+	}
+
+	oldFiles, err := scanRevision(ctx, repo, oldRef, languages)
+	if err != nil {
+		return nil, err
+	}
+	newFiles, err := scanRevision(ctx, repo, newRef, languages)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{}
+	oldLines, newLines := make(map[string]int), make(map[string]int)
+	oldLanguages, newLanguages := make(map[string]int), make(map[string]int)
+	for name, file := range oldFiles {
+		oldLines[name] = file.codeLines
+		oldLanguages[file.language] += file.codeLines
+		report.OldTotal += file.codeLines
+	}
+	for name, file := range newFiles {
+		newLines[name] = file.codeLines
+		newLanguages[file.language] += file.codeLines
+		report.NewTotal += file.codeLines
+	}
+
+	for _, change := range compare(oldLines, newLines) {
+		file := FileDiff{Change: change, Language: newFiles[change.Name].language}
+		if change.Status == Removed {
+			file.Language = oldFiles[change.Name].language
+		}
+		report.Files = append(report.Files, file)
+	}
+	report.Languages = compare(oldLanguages, newLanguages)
+	report.Delta = report.NewTotal - report.OldTotal
+
+	repoChange := Change{Name: repoName(src), OldLines: report.OldTotal, NewLines: report.NewTotal, Delta: report.Delta, Status: Unchanged}
+	if report.Delta != 0 {
+		repoChange.Status = Changed
+	}
+	report.Repos = []RepoDiff{{Change: repoChange, Languages: report.Languages}}
+
+	return report, nil
+}
+
+// Scan the files of a revision, by path relative to the repository
+func scanRevision(ctx context.Context, repo *gogit.Repository, rev string, languages language.Languages) (map[string]fileLines, error) {
+	dir, _, err := gogit.ExportRevision(repo, rev)
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	gc, err := goloc.NewGClocContext(ctx, goloc.Params{Path: dir, ByFile: true, Quiet: true}, languages)
+	if err != nil {
+		return nil, err
+	}
+	result, err := gc.RunContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error scanning %s: %w", rev, err)
+	}
+
+	files := make(map[string]fileLines)
+	for _, file := range result.Summary.Files {
+		name, err := filepath.Rel(gc.Repopath, file.Path)
+		if err != nil {
+			return nil, err
+		}
+		files[filepath.ToSlash(name)] = fileLines{language: file.Language, codeLines: file.CodeLines}
+	}
+	return files, nil
+}
+
+// Name of a repository from its path or URL
+func repoName(src string) string {
+	if path, err := filepath.Abs(src); err == nil {
+		if _, err := os.Stat(path); err == nil {
+			src = path
+		}
+	}
+	return strings.TrimSuffix(filepath.Base(strings.TrimRight(src, "/")), ".git")
+}
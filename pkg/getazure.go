@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -300,7 +301,7 @@ func GetRepoAzureList(platformConfig map[string]interface{}, exclusionFile strin
 		NumRepositories: nbRepos,
 		ProjectBranches: importantBranches,
 	}
-	if err := SaveResult(result); err != nil {
+	if err := SaveResult(result, platformConfig["ResultsDir"].(string)); err != nil {
 		fmt.Println("❌ Error Save Result of Analysis :", err)
 		os.Exit(1)
 	}
@@ -694,9 +695,10 @@ func getCommitCount(ctx context.Context, gitClient git.Client, projectID string,
 
 	return totalCommits, nil
 }
-func SaveResult(result AnalysisResult) error {
+func SaveResult(result AnalysisResult, resultsDir string) error {
 	// Open or create the file
-	file, err := os.Create("Results/config/analysis_result_azure.json")
+	filePath := filepath.Join(resultsDir, "config", "analysis_result_azure.json")
+	file, err := os.Create(filePath)
 	if err != nil {
 		fmt.Println("❌ Error creating Analysis file:", err)
 		return err
@@ -708,7 +710,7 @@ func SaveResult(result AnalysisResult) error {
 
 	// Encode the result and write it to the file
 	if err := encoder.Encode(result); err != nil {
-		fmt.Println("❌ Error encoding JSON file <Results/config/analysis_result_azure.json> :", err)
+		fmt.Printf("❌ Error encoding JSON file <%s> : %v\n", filePath, err)
 		return err
 	}
 
@@ -1,6 +1,7 @@
 package getter
 
 import (
+	"context"
 	"crypto/rand"
 	"encoding/hex"
 	"fmt"
@@ -20,14 +21,27 @@ func extractLastString(url string) string {
 }
 
 func Getter(src string) (string, error) {
-	RepoString := extractLastString(src)
+	return GetterContext(context.Background(), src, false)
+}
+
+// GetterContext is Getter with a context to cancel the download.
+// The spinner is not shown when quiet is true.
+func GetterContext(ctx context.Context, src string, quiet bool) (string, error) {
+	// A local directory is scanned in place, go-getter would only link it in the temporary directory
+	if info, err := os.Stat(src); err == nil && info.IsDir() {
+		return filepath.Abs(src)
+	}
 
-	spinner := newSpinner(fmt.Sprintf("\r Extracting files from %s \n", RepoString))
-	spinner.Color("green", "bold")
-	messageF := ""
-	spinner.FinalMSG = messageF
-	spinner.Start()
-	defer spinner.Stop()
+	if !quiet {
+		RepoString := extractLastString(src)
+
+		spinner := newSpinner(fmt.Sprintf("\r Extracting files from %s \n", RepoString))
+		spinner.Color("green", "bold")
+		messageF := ""
+		spinner.FinalMSG = messageF
+		spinner.Start()
+		defer spinner.Stop()
+	}
 
 	suffix, err := randomSuffix()
 	if err != nil {
@@ -41,6 +55,7 @@ func Getter(src string) (string, error) {
 	}
 
 	client := &getter.Client{
+		Ctx: ctx,
 		Src: src,
 		Dst: dst,
 		Pwd: pwd,
@@ -1,21 +1,45 @@
 package gogit
 
 import (
+	"context"
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
 	"log"
+	"net"
+	"net/http"
 	"os"
 	"path/filepath"
 
 	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/go-git/go-git/v5/plumbing/protocol/packp/capability"
 	"github.com/go-git/go-git/v5/plumbing/transport"
-	//"github.com/go-git/go-git/v5/plumbing/transport/http"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/storage/memory"
 )
 
+// The clone options are global in go-git, they are set once so that the clones
+// of the parallel workers do not race on them
+func init() {
+	log.SetOutput(os.Stderr)
+	transport.UnsupportedCapabilities = []capability.Capability{
+		capability.ThinPack,
+	}
+}
+
 func Getrepos(src, branch, token string) (string, error) {
+	return GetreposContext(context.Background(), src, branch, token)
+}
+
+// Clone a branch of a repository in a temporary directory.
+// The directory is removed if the clone fails or ctx is done.
+func GetreposContext(ctx context.Context, src, branch, token string) (string, error) {
 
 	suffix, err := randomSuffix()
 	if err != nil {
@@ -23,17 +47,8 @@ func Getrepos(src, branch, token string) (string, error) {
 	}
 
 	dst := filepath.Join(os.TempDir(), fmt.Sprintf("gcloc-extract-%s", suffix))
-	//pwd, err := os.Getwd()
-	if err != nil {
-		return "", err
-	}
-	log.SetOutput(os.Stderr)
 
-	transport.UnsupportedCapabilities = []capability.Capability{
-		capability.ThinPack,
-	}
-
-	_, err = git.PlainClone(dst, false, &git.CloneOptions{
+	_, err = git.PlainCloneContext(ctx, dst, false, &git.CloneOptions{
 		URL: src,
 
 		ReferenceName: plumbing.NewBranchReferenceName(branch),
@@ -44,7 +59,8 @@ func Getrepos(src, branch, token string) (string, error) {
 	})
 
 	if err != nil {
-		fmt.Printf("\n--❌ Stack: gogit.Getrepos Git Branch %s - %s-- Source: %s -", plumbing.Main, err, src)
+		os.RemoveAll(dst)
+		return "", fmt.Errorf("clone of branch %s failed: %w", branch, err)
 	}
 
 	symLink, err := isSymLink(dst)
@@ -64,6 +80,40 @@ func Getrepos(src, branch, token string) (string, error) {
 	return dst, nil
 }
 
+// Return true for the network errors which may succeed on a new attempt : connection errors,
+// truncated responses, server errors and rate limits. The other errors would fail again, and
+// a repository whose deadline is exceeded is not retried.
+func IsTransient(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	status := httpStatus(err)
+	return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
+
+// Return the HTTP status of a go-git transport error, or 0
+func httpStatus(err error) int {
+	// go-git returns the statuses other than 401, 403 and 404 in an UnexpectedError, which does not unwrap
+	var unexpected *plumbing.UnexpectedError
+	if errors.As(err, &unexpected) {
+		err = unexpected.Err
+	}
+	var httpErr *githttp.Err
+	if errors.As(err, &httpErr) && httpErr.Response != nil {
+		return httpErr.Response.StatusCode
+	}
+	return 0
+}
+
 func randomSuffix() (string, error) {
 	randBytes := make([]byte, 16)
 	_, err := rand.Read(randBytes)
@@ -82,3 +132,138 @@ func isSymLink(path string) (bool, error) {
 
 	return info.Mode()&os.ModeSymlink != 0, nil
 }
+
+// Return the commit of a branch of a remote repository, without cloning it
+func RemoteCommit(src, branch string) (string, error) {
+	return RemoteCommitContext(context.Background(), src, branch, "")
+}
+
+// Return the commit of a branch of a remote repository, without cloning it.
+// The token authenticates the request when src has no credentials.
+func RemoteCommitContext(ctx context.Context, src, branch, token string) (string, error) {
+	remote := git.NewRemote(memory.NewStorage(), &config.RemoteConfig{
+		Name: git.DefaultRemoteName,
+		URLs: []string{src},
+	})
+
+	refs, err := remote.ListContext(ctx, &git.ListOptions{Auth: tokenAuth(src, token)})
+	if err != nil {
+		return "", err
+	}
+
+	name := plumbing.NewBranchReferenceName(branch)
+	for _, ref := range refs {
+		if ref.Name() == name {
+			return ref.Hash().String(), nil
+		}
+	}
+
+	return "", fmt.Errorf("branch %s not found", branch)
+}
+
+// Basic authentication with a token for a http(s) URL without credentials, the credentials of the URL are used otherwise
+func tokenAuth(src, token string) transport.AuthMethod {
+	if token == "" {
+		return nil
+	}
+	ep, err := transport.NewEndpoint(src)
+	if err != nil || ep.User != "" || (ep.Protocol != "http" && ep.Protocol != "https") {
+		return nil
+	}
+	// The username is ignored with a token by GitHub, GitLab and Azure, Bitbucket Cloud requires this one
+	return &githttp.BasicAuth{Username: "x-token-auth", Password: token}
+}
+
+// Return the commit checked out in a cloned repository
+func HeadCommit(path string) (string, error) {
+	repo, err := git.PlainOpen(path)
+	if err != nil {
+		return "", err
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return "", err
+	}
+
+	return head.Hash().String(), nil
+}
+
+// Repository opened or cloned in memory
+type Repository = git.Repository
+
+// Open a local repository, or clone a remote one in memory with its whole history
+func OpenContext(ctx context.Context, src string) (*Repository, error) {
+	if info, err := os.Stat(src); err == nil && info.IsDir() {
+		return git.PlainOpenWithOptions(src, &git.PlainOpenOptions{DetectDotGit: true})
+	}
+
+	repo, err := git.CloneContext(ctx, memory.NewStorage(), nil, &git.CloneOptions{URL: src})
+	if err != nil {
+		return nil, fmt.Errorf("clone of %s failed: %w", src, err)
+	}
+	return repo, nil
+}
+
+// Write the files of a branch, tag or commit of a repository in a temporary directory.
+// Return the directory and the commit of the revision.
+func ExportRevision(repo *Repository, rev string) (string, string, error) {
+	hash, err := repo.ResolveRevision(plumbing.Revision(rev))
+	if err != nil {
+		// The branches of a clone are remote branches
+		if hash, err = repo.ResolveRevision(plumbing.Revision(git.DefaultRemoteName + "/" + rev)); err != nil {
+			return "", "", fmt.Errorf("revision %s not found: %w", rev, err)
+		}
+	}
+
+	commit, err := repo.CommitObject(*hash)
+	if err != nil {
+		return "", "", err
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return "", "", err
+	}
+
+	suffix, err := randomSuffix()
+	if err != nil {
+		return "", "", err
+	}
+	dst := filepath.Join(os.TempDir(), fmt.Sprintf("gcloc-extract-%s", suffix))
+
+	err = tree.Files().ForEach(func(file *object.File) error {
+		// The links may point outside of the revision
+		if file.Mode == filemode.Symlink {
+			return nil
+		}
+		path := filepath.Join(dst, filepath.FromSlash(file.Name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		return exportFile(file, path)
+	})
+	if err != nil {
+		os.RemoveAll(dst)
+		return "", "", err
+	}
+
+	return dst, hash.String(), nil
+}
+
+func exportFile(file *object.File, path string) error {
+	reader, err := file.Reader()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	writer, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(writer, reader); err != nil {
+		writer.Close()
+		return err
+	}
+	return writer.Close()
+}
@@ -1,8 +1,13 @@
 package goloc
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/colussim/GoLC/pkg/analyzer"
 	"github.com/colussim/GoLC/pkg/filesystem"
@@ -10,8 +15,12 @@ import (
 	"github.com/colussim/GoLC/pkg/gogit"
 	"github.com/colussim/GoLC/pkg/goloc/language"
 	"github.com/colussim/GoLC/pkg/reporter"
-	"github.com/colussim/GoLC/pkg/reporter/json"
-	"github.com/colussim/GoLC/pkg/reporter/prompt"
+	_ "github.com/colussim/GoLC/pkg/reporter/cloc"
+	_ "github.com/colussim/GoLC/pkg/reporter/csv"
+	_ "github.com/colussim/GoLC/pkg/reporter/json"
+	_ "github.com/colussim/GoLC/pkg/reporter/markdown"
+	_ "github.com/colussim/GoLC/pkg/reporter/openmetrics"
+	_ "github.com/colussim/GoLC/pkg/reporter/prompt"
 	"github.com/colussim/GoLC/pkg/scanner"
 	"github.com/colussim/GoLC/pkg/sorter"
 	"github.com/colussim/GoLC/pkg/utils"
@@ -35,37 +44,75 @@ type Params struct {
 	ReportFormats     []string
 	Branch            string
 	Token             string
+	Quiet             bool        // No spinner, progress bar or message on stdout
+	Progress          func(Event) // Called for each progress event, may be nil
+
+	// Open the output of a report. When nil, the reports with an extension are written
+	// to OutputPath/OutputName<extension> and the others to stdout.
+	Output func(format reporter.Format) (io.WriteCloser, error)
+}
+
+// Kinds of progress events
+const (
+	EventFetch   = "fetch"   // Cloning or downloading the source
+	EventFetched = "fetched" // Source available in Path
+	EventScan    = "scan"    // Files matched, Total is the number of files to scan
+	EventFile    = "file"    // File scanned, Done of Total
+	EventDone    = "done"    // Scan finished and reports generated
+)
+
+// Progress event of an analysis
+type Event struct {
+	Kind  string
+	Path  string
+	Done  int
+	Total int
+}
+
+// Result of an analysis
+type Result struct {
+	Summary *scanner.Summary
+	Sorted  *sorter.SortedSummary
 }
 
 type GCloc struct {
-	params    Params
-	analyzer  *analyzer.Analyzer
-	scanner   *scanner.Scanner
-	sorter    sorter.Sorter
-	reporters []reporter.Reporter
-	Repopath  string
+	params   Params
+	analyzer *analyzer.Analyzer
+	scanner  *scanner.Scanner
+	sorter   sorter.Sorter
+	formats  []reporter.Format
+	Repopath string
 }
 
 func NewGCloc(params Params, languages language.Languages) (*GCloc, error) {
+	return NewGClocContext(context.Background(), params, languages)
+}
+
+// NewGClocContext is NewGCloc with a context to cancel the clone of the repository
+func NewGClocContext(ctx context.Context, params Params, languages language.Languages) (*GCloc, error) {
 	var path string
 	var err error
 
+	formats, err := getFormats(params.ReportFormats)
+	if err != nil {
+		return nil, err
+	}
+
+	notify(params, Event{Kind: EventFetch, Path: params.Path})
 	if len(params.Branch) != 0 {
-		path, err = gogit.Getrepos(params.Path, params.Branch, params.Token)
+		path, err = gogit.GetreposContext(ctx, params.Path, params.Branch, params.Token)
 		if err != nil {
-			//return nil, err
-			fmt.Println(err)
+			return nil, err
 		}
 	} else {
-		path, err = getter.Getter(params.Path)
+		path, err = getter.GetterContext(ctx, params.Path, params.Quiet)
 		if err != nil {
 			return nil, err
 		}
 		lastPart := filepath.Base(path)
 		if lastPart != "" {
 			params.OutputName = fmt.Sprintf("%s%s", params.OutputName, lastPart)
-			fmt.Println("OutputName:", params.OutputName)
-		} else {
+		} else if !params.Quiet {
 			fmt.Println("OutputName:", path)
 			fmt.Println("\n❌ Failed to create OutputName")
 		}
@@ -79,6 +126,8 @@ func NewGCloc(params Params, languages language.Languages) (*GCloc, error) {
 
 			}*/
 	}
+	notify(params, Event{Kind: EventFetched, Path: path})
+
 	excludePaths, err := filesystem.GetExcludePaths(path, params.ExcludePaths)
 	if err != nil {
 		return nil, err
@@ -93,37 +142,63 @@ func NewGCloc(params Params, languages language.Languages) (*GCloc, error) {
 	)
 
 	scanner := scanner.NewScanner(languages)
+	scanner.Quiet = params.Quiet
+	if params.Progress != nil {
+		scanner.OnFile = func(file string, done, total int) {
+			params.Progress(Event{Kind: EventFile, Path: file, Done: done, Total: total})
+		}
+	}
 
 	sorter := getSorter(params.ByFile, params.Order)
 
-	reporters := getReporters(params.ReportFormats, params.OutputName, params.OutputPath)
-
 	return &GCloc{
-		params:    params,
-		analyzer:  analyzer,
-		scanner:   scanner,
-		sorter:    sorter,
-		reporters: reporters,
-		Repopath:  path,
+		params:   params,
+		analyzer: analyzer,
+		scanner:  scanner,
+		sorter:   sorter,
+		formats:  formats,
+		Repopath: path,
 	}, nil
 }
 
 func (gc *GCloc) Run() error {
-	files, err := gc.analyzer.MatchingFiles()
+	_, err := gc.RunContext(context.Background())
+	return err
+}
+
+// RunContext scans the source, generates the configured reports and returns the summaries.
+// The scan stops with ctx.Err() when ctx is done.
+func (gc *GCloc) RunContext(ctx context.Context) (*Result, error) {
+	start := time.Now()
+
+	files, err := gc.analyzer.MatchingFilesContext(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	notify(gc.params, Event{Kind: EventScan, Path: gc.Repopath, Total: len(files)})
 
-	scanResult, err := gc.scanner.Scan(files)
+	scanResult, err := gc.scanner.ScanContext(ctx, files)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	summary := gc.scanner.Summary(scanResult)
 
 	sortedSummary := gc.sortSummary(summary)
+	sortedSummary.Elapsed = time.Since(start)
+
+	if err := gc.generateReports(sortedSummary); err != nil {
+		return nil, err
+	}
+	notify(gc.params, Event{Kind: EventDone, Path: gc.Repopath, Done: len(files), Total: len(files)})
 
-	return gc.generateReports(sortedSummary)
+	return &Result{Summary: summary, Sorted: sortedSummary}, nil
+}
+
+func notify(params Params, event Event) {
+	if params.Progress != nil {
+		params.Progress(event)
+	}
 }
 
 func (gc *GCloc) ChangeLanguages(languages language.Languages) {
@@ -165,24 +240,80 @@ func (gc *GCloc) sortSummary(summary *scanner.Summary) *sorter.SortedSummary {
 }
 
 func (gc *GCloc) generateReports(sortedSummary *sorter.SortedSummary) error {
-	if gc.params.ByFile {
-		for _, reporter := range gc.reporters {
-			if err := reporter.GenerateReportByFile(sortedSummary); err != nil {
-				return err
-			}
+	for _, format := range gc.formats {
+		if err := gc.generateReport(format, sortedSummary); err != nil {
+			return err
 		}
-		return nil
 	}
 
-	for _, reporter := range gc.reporters {
-		if err := reporter.GenerateReportByLanguage(sortedSummary); err != nil {
-			return err
-		}
+	return nil
+}
+
+func (gc *GCloc) generateReport(format reporter.Format, sortedSummary *sorter.SortedSummary) error {
+	w, path, err := gc.openOutput(format)
+	if err != nil {
+		return err
+	}
+
+	r := format.New()
+	if gc.params.ByFile {
+		err = r.GenerateReportByFile(w, sortedSummary)
+	} else {
+		err = r.GenerateReportByLanguage(w, sortedSummary)
+	}
+	if cerr := w.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+
+	if path != "" && !gc.params.Quiet {
+		fmt.Printf("\n\t✅ %s report exported to %s\n", format.Name, path)
 	}
 
 	return nil
 }
 
+// Open the output of a report, path is empty when it is not a file
+func (gc *GCloc) openOutput(format reporter.Format) (io.WriteCloser, string, error) {
+	if gc.params.Output != nil {
+		w, err := gc.params.Output(format)
+		return w, "", err
+	}
+
+	if format.Extension == "" {
+		return nopCloser{os.Stdout}, "", nil
+	}
+
+	path := ReportPath(gc.params.OutputPath, gc.params.OutputName, format.Extension)
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, "", err
+	}
+	return file, path, nil
+}
+
+// ReportPath returns the file of a report: "/" in the name are replaced by "_"
+func ReportPath(outputPath, outputName, extension string) string {
+	outputName = strings.Replace(outputName, "/", "_", -1)
+	if !strings.HasSuffix(outputName, extension) {
+		outputName += extension
+	}
+	return filepath.Join(outputPath, outputName)
+}
+
+// ReportPath returns the file of the report of gc in a format
+func (gc *GCloc) ReportPath(extension string) string {
+	return ReportPath(gc.params.OutputPath, gc.params.OutputName, extension)
+}
+
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error { return nil }
+
 func getExtensionsMap(languages language.Languages) map[string]string {
 	extensions := map[string]string{}
 
@@ -203,22 +334,24 @@ func getSorter(byFile bool, order string) sorter.Sorter {
 	return sorter.NewLanguageSorter(order)
 }
 
-func getReporters(reportFormats []string, outputName, outputPath string) []reporter.Reporter {
-	var reporters []reporter.Reporter
-
-	for _, format := range reportFormats {
-		switch format {
-		case "prompt":
-			reporters = append(reporters, prompt.PromptReporter{})
-		case "json":
-			reporters = append(reporters, json.JsonReporter{
-				OutputName: outputName,
-				OutputPath: outputPath,
-			})
-		default:
-			fmt.Printf("%s report format not supported\n", format)
+func getFormats(reportFormats []string) ([]reporter.Format, error) {
+	var formats []reporter.Format
+	// Format writing each report file, two formats would overwrite the same file
+	written := make(map[string]string)
+
+	for _, name := range reportFormats {
+		format, err := reporter.Lookup(name)
+		if err != nil {
+			return nil, err
+		}
+		if format.Extension != "" {
+			if other, ok := written[format.Extension]; ok {
+				return nil, fmt.Errorf("the report formats %s and %s both write the %s report", other, name, format.Extension)
+			}
+			written[format.Extension] = name
 		}
+		formats = append(formats, format)
 	}
 
-	return reporters
+	return formats, nil
 }
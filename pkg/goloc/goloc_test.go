@@ -0,0 +1,36 @@
+package goloc
+
+import (
+	"testing"
+)
+
+func TestGetFormats(t *testing.T) {
+	tests := []struct {
+		name    string
+		formats []string
+		want    []string // Extensions of the formats
+		wantErr bool
+	}{
+		{"default", []string{"prompt"}, []string{""}, false},
+		{"with and without totals", []string{"csv", "csv-nototals", "tsv", "tsv-nototals"}, []string{".csv", ".nototals.csv", ".tsv", ".nototals.tsv"}, false},
+		{"json and cloc-json", []string{"json", "cloc-json"}, []string{".json", ".cloc.json"}, false},
+		{"same format twice", []string{"csv", "csv"}, nil, true},
+		{"unknown format", []string{"html"}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			formats, err := getFormats(tt.formats)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getFormats(%v) error %v, want error %v", tt.formats, err, tt.wantErr)
+			}
+			if len(formats) != len(tt.want) {
+				t.Fatalf("getFormats(%v) = %d formats, want %d", tt.formats, len(formats), len(tt.want))
+			}
+			for i, format := range formats {
+				if format.Extension != tt.want[i] {
+					t.Errorf("extension of %s = %q, want %q", format.Name, format.Extension, tt.want[i])
+				}
+			}
+		})
+	}
+}
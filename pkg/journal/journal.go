@@ -0,0 +1,162 @@
+package journal
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// Entry of a repository whose analysis is finished
+type Entry struct {
+	ProjectKey string    `json:"ProjectKey"`
+	Namespace  string    `json:"Namespace,omitempty"`
+	RepoSlug   string    `json:"RepoSlug"`
+	Branch     string    `json:"Branch"`
+	Commit     string    `json:"Commit"`
+	ResultFile string    `json:"ResultFile"`
+	Time       time.Time `json:"Time"`
+}
+
+// Key identifying the repository of an entry
+func (e Entry) Key() string {
+	return Key(e.ProjectKey, e.Namespace, e.RepoSlug)
+}
+
+func Key(projectKey, namespace, repoSlug string) string {
+	if namespace != "" {
+		return namespace
+	}
+	return projectKey + "/" + repoSlug
+}
+
+// Journal records one JSON line per finished repository.
+// Every entry is synced to disk so that it survives a crash of the run.
+type Journal struct {
+	mu      sync.Mutex
+	file    *os.File
+	entries map[string]Entry
+	order   []string
+}
+
+// Open the journal file. Existing entries are kept when resume is true, otherwise the file is truncated.
+func Open(path string, resume bool) (*Journal, error) {
+	j := &Journal{entries: make(map[string]Entry)}
+
+	flags := os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	if resume {
+		if err := j.load(path); err != nil {
+			return nil, err
+		}
+	} else {
+		flags |= os.O_TRUNC
+	}
+
+	file, err := os.OpenFile(path, flags, 0644)
+	if err != nil {
+		return nil, err
+	}
+	j.file = file
+
+	return j, nil
+}
+
+// Read the entries of a journal file without opening it for writing
+func Read(path string) ([]Entry, error) {
+	j := &Journal{entries: make(map[string]Entry)}
+	if err := j.load(path); err != nil {
+		return nil, err
+	}
+	return j.Entries(), nil
+}
+
+func (j *Journal) load(path string) error {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			// The last line may be truncated by a crash
+			fmt.Printf("❗️ Ignoring invalid journal line %d: %v\n", line, err)
+			continue
+		}
+		j.set(entry)
+	}
+	return scanner.Err()
+}
+
+func (j *Journal) set(entry Entry) {
+	key := entry.Key()
+	if _, ok := j.entries[key]; !ok {
+		j.order = append(j.order, key)
+	}
+	j.entries[key] = entry
+}
+
+// Record a finished repository
+func (j *Journal) Record(entry Entry) error {
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if _, err := j.file.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	if err := j.file.Sync(); err != nil {
+		return err
+	}
+	j.set(entry)
+
+	return nil
+}
+
+// Return the entry of a repository if it is already done at the given commit
+func (j *Journal) Done(key, commit string) (Entry, bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	entry, ok := j.entries[key]
+	if !ok || commit == "" || entry.Commit != commit {
+		return Entry{}, false
+	}
+	return entry, true
+}
+
+// Return the latest entry of every repository, in the order they were first recorded
+func (j *Journal) Entries() []Entry {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	entries := make([]Entry, 0, len(j.order))
+	for _, key := range j.order {
+		entries = append(entries, j.entries[key])
+	}
+	return entries
+}
+
+func (j *Journal) Close() error {
+	return j.file.Close()
+}
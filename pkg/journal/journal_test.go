@@ -0,0 +1,94 @@
+package journal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestRecordConcurrent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.jsonl")
+	j, err := Open(path, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			entry := Entry{ProjectKey: "PRJ", RepoSlug: fmt.Sprintf("repo%d", i), Commit: "abc"}
+			if err := j.Record(entry); err != nil {
+				t.Error(err)
+			}
+			j.Done(entry.Key(), "abc")
+			j.Entries()
+		}(i)
+	}
+	wg.Wait()
+	j.Close()
+
+	entries, err := Read(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 50 {
+		t.Errorf("read %d entries, want 50", len(entries))
+	}
+}
+
+func TestResume(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.jsonl")
+	j, err := Open(path, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	j.Record(Entry{ProjectKey: "PRJ", RepoSlug: "a", Commit: "1"})
+	j.Record(Entry{ProjectKey: "PRJ", RepoSlug: "b", Commit: "1"})
+	j.Record(Entry{ProjectKey: "PRJ", RepoSlug: "a", Commit: "2"})
+	j.Close()
+
+	// A crash may leave a truncated last line
+	file, _ := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	file.WriteString(`{"ProjectKey":"PRJ","Repo`)
+	file.Close()
+
+	j, err = Open(path, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer j.Close()
+
+	entries := j.Entries()
+	if len(entries) != 2 || entries[0].RepoSlug != "a" || entries[1].RepoSlug != "b" {
+		t.Fatalf("entries %+v, want a then b", entries)
+	}
+	if _, ok := j.Done("PRJ/a", "2"); !ok {
+		t.Error("PRJ/a is not done at its latest commit")
+	}
+	if _, ok := j.Done("PRJ/a", "1"); ok {
+		t.Error("PRJ/a is done at a previous commit")
+	}
+
+	// Without resume the journal starts empty
+	j2, err := Open(filepath.Join(filepath.Dir(path), "other.jsonl"), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer j2.Close()
+	if len(j2.Entries()) != 0 {
+		t.Error("a new journal has entries")
+	}
+}
+
+func TestKey(t *testing.T) {
+	if key := Key("PRJ", "", "repo"); key != "PRJ/repo" {
+		t.Errorf("Key = %s, want PRJ/repo", key)
+	}
+	if key := Key("group", "group/sub/repo", "repo"); key != "group/sub/repo" {
+		t.Errorf("Key = %s, want the namespace", key)
+	}
+}
@@ -0,0 +1,246 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/colussim/GoLC/pkg/journal"
+	"github.com/colussim/GoLC/pkg/utils"
+)
+
+// Breakdown of an aggregated report, relative to its results directory
+const AggregateFile = "AggregateReport.json"
+
+// Results directory of an organization merged in an aggregated report
+type AggregateSource struct {
+	Directory    string `json:"Directory"`
+	Platform     string `json:"Platform"`
+	Organization string `json:"Organization"`
+	Repositories int    `json:"Repositories"` // Without the duplicates
+	CodeLines    int    `json:"CodeLines"`
+	Duplicates   int    `json:"Duplicates"`
+}
+
+// Totals of the organizations of a platform
+type AggregatePlatform struct {
+	Platform      string   `json:"Platform"`
+	Organizations []string `json:"Organizations"`
+	Repositories  int      `json:"Repositories"`
+	CodeLines     int      `json:"CodeLines"`
+}
+
+// Repository mirrored on several platforms, it is counted once
+type AggregateDuplicate struct {
+	Repository string `json:"Repository"` // platform/organization/project/repository skipped
+	MirrorOf   string `json:"MirrorOf"`   // platform/organization/project/repository kept
+}
+
+// Breakdown per platform and organization of the results directories merged in one
+type AggregateReport struct {
+	Sources      []AggregateSource    `json:"Sources"`
+	Platforms    []AggregatePlatform  `json:"Platforms"`
+	Duplicates   []AggregateDuplicate `json:"Duplicates"`
+	Repositories int                  `json:"Repositories"`
+	CodeLines    int                  `json:"CodeLines"`
+}
+
+// Repository of an aggregated report with the results directory it comes from
+type aggregateRepo struct {
+	repo   RepoData
+	entry  journal.Entry
+	source int
+}
+
+// Key of a mirrored repository : the same commit, or the same name and counts without commit
+func mirrorKey(repo RepoData) string {
+	if repo.Commit != "" {
+		return "commit:" + repo.Commit
+	}
+	return fmt.Sprintf("name:%s:%d:%d", strings.ToLower(repo.Repository), repo.TotalFiles, repo.TotalCodeLines)
+}
+
+func (r aggregateRepo) name(sources []AggregateSource) string {
+	source := sources[r.source]
+	return strings.Join([]string{source.Platform, source.Organization, HistoryRepoName(r.repo.Project, r.repo.Repository)}, "/")
+}
+
+// Merge results directories of several platforms and organizations in the results directory output.
+// The repositories mirrored on several platforms are kept once, from the first directory.
+// The result files are copied with a journal and a GlobalReport.json, so that output is read
+// like the results of a run, and the breakdown is written in output/AggregateReport.json.
+func Aggregate(output string, directories []string) (*AggregateReport, error) {
+	aggregate := &AggregateReport{Duplicates: []AggregateDuplicate{}}
+	var repos []aggregateRepo
+	kept := make(map[string]aggregateRepo)
+
+	for i, directory := range directories {
+		Ginfo, err := LoadGlobalReport(directory)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", directory, err)
+		}
+		entries, err := resultEntries(directory)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", directory, err)
+		}
+		results, err := LoadRepos(directory)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", directory, err)
+		}
+
+		source := AggregateSource{Directory: directory, Platform: Ginfo.DevOpsPlatform, Organization: Ginfo.Organization}
+		aggregate.Sources = append(aggregate.Sources, source)
+
+		// LoadRepos keeps the order of the entries
+		for n, repo := range results {
+			r := aggregateRepo{repo: repo, entry: entries[n], source: i}
+			key := mirrorKey(repo)
+			if mirror, ok := kept[key]; ok && mirror.source != i {
+				aggregate.Duplicates = append(aggregate.Duplicates, AggregateDuplicate{
+					Repository: r.name(aggregate.Sources),
+					MirrorOf:   mirror.name(aggregate.Sources),
+				})
+				aggregate.Sources[i].Duplicates++
+				continue
+			}
+			if _, ok := kept[key]; !ok {
+				kept[key] = r
+			}
+			repos = append(repos, r)
+			aggregate.Sources[i].Repositories++
+			aggregate.Sources[i].CodeLines += repo.TotalCodeLines
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Join(output, filepath.Dir(journalPath)), 0755); err != nil {
+		return nil, err
+	}
+	jr, err := journal.Open(filepath.Join(output, journalPath), false)
+	if err != nil {
+		return nil, err
+	}
+	defer jr.Close()
+
+	var largest *aggregateRepo
+	// The same project and repository names may be used by several organizations and platforms
+	used, keys := make(map[string]bool), make(map[string]bool)
+	for i, r := range repos {
+		source := aggregate.Sources[r.source]
+		prefix := source.Platform + "/" + source.Organization
+
+		name := r.entry.ResultFile
+		for n := 1; used[name]; n++ {
+			name = "Result_" + strings.ReplaceAll(uniqueName(prefix, n), "/", "_") + "_" + strings.TrimPrefix(r.entry.ResultFile, "Result_")
+		}
+		used[name] = true
+
+		if err := copyFile(filepath.Join(directories[r.source], r.entry.ResultFile), filepath.Join(output, name)); err != nil {
+			return nil, err
+		}
+
+		entry := r.entry
+		entry.ResultFile = name
+		for n := 1; keys[entry.Key()]; n++ {
+			entry.ProjectKey = uniqueName(prefix, n)
+			if r.entry.ProjectKey != "" {
+				entry.ProjectKey += "/" + r.entry.ProjectKey
+			}
+			if r.entry.Namespace != "" {
+				entry.Namespace = uniqueName(prefix, n) + "/" + r.entry.Namespace
+			}
+		}
+		keys[entry.Key()] = true
+		r.entry = entry
+		if err := jr.Record(r.entry); err != nil {
+			return nil, err
+		}
+
+		aggregate.Repositories++
+		aggregate.CodeLines += r.repo.TotalCodeLines
+		if largest == nil || r.repo.TotalCodeLines > largest.repo.TotalCodeLines {
+			largest = &repos[i]
+		}
+	}
+
+	platforms := make(map[string]*AggregatePlatform)
+	var organizations, names []string
+	for _, source := range aggregate.Sources {
+		platform := platforms[source.Platform]
+		if platform == nil {
+			platform = &AggregatePlatform{Platform: source.Platform}
+			platforms[source.Platform] = platform
+			names = append(names, source.Platform)
+		}
+		platform.Organizations = append(platform.Organizations, source.Organization)
+		platform.Repositories += source.Repositories
+		platform.CodeLines += source.CodeLines
+		organizations = append(organizations, source.Organization)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		aggregate.Platforms = append(aggregate.Platforms, *platforms[name])
+	}
+
+	Ginfo := Globalinfo{
+		Organization:     strings.Join(organizations, ", "),
+		TotalLinesOfCode: utils.FormatCodeLines(float64(aggregate.CodeLines)),
+		DevOpsPlatform:   strings.Join(names, ", "),
+		NumberRepos:      aggregate.Repositories,
+	}
+	if largest != nil {
+		Ginfo.LargestRepository = largest.repo.Repository
+		Ginfo.LinesOfCodeLargestRepo = utils.FormatCodeLines(float64(largest.repo.TotalCodeLines))
+	}
+	// The projects are read back with the keys of the journal
+	merged, err := LoadRepos(output)
+	if err != nil {
+		return nil, err
+	}
+	Ginfo.Projects = Projects(merged)
+	if err := writeJSONFile(filepath.Join(output, "GlobalReport.json"), Ginfo); err != nil {
+		return nil, err
+	}
+	if err := writeJSONFile(filepath.Join(output, AggregateFile), aggregate); err != nil {
+		return nil, err
+	}
+
+	return aggregate, nil
+}
+
+// Prefix of a repository whose name is already used, numbered from the second try
+func uniqueName(prefix string, n int) string {
+	if n == 1 {
+		return prefix
+	}
+	return fmt.Sprintf("%s-%d", prefix, n)
+}
+
+func writeJSONFile(path string, value interface{}) error {
+	data, err := json.MarshalIndent(value, "", "    ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
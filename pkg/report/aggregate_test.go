@@ -0,0 +1,183 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/colussim/GoLC/pkg/journal"
+)
+
+type testRepo struct {
+	metadata  ResultMetadata
+	codeLines map[string]int
+}
+
+// Results directory of an organization with its journal and GlobalReport.json
+func writeSource(t *testing.T, platform, organization string, repos ...testRepo) string {
+	t.Helper()
+	dir := t.TempDir()
+	var entries []journal.Entry
+	for _, repo := range repos {
+		name := "Result_" + repo.metadata.Project + "_" + repo.metadata.Repository + "_" + repo.metadata.Branch + ".json"
+		writeResult(t, dir, name, &repo.metadata, repo.codeLines)
+		entries = append(entries, repo.metadata.Entry(name))
+	}
+	writeJournal(t, dir, entries...)
+	if err := writeJSONFile(filepath.Join(dir, "GlobalReport.json"), Globalinfo{DevOpsPlatform: platform, Organization: organization}); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func repo(project, name, commit string, codeLines map[string]int) testRepo {
+	return testRepo{metadata: ResultMetadata{Project: project, Repository: name, Branch: "main", Commit: commit}, codeLines: codeLines}
+}
+
+func TestAggregateMirrors(t *testing.T) {
+	go100 := map[string]int{"Go": 100}
+	js50 := map[string]int{"JavaScript": 50}
+
+	tests := []struct {
+		name         string
+		sources      [][]testRepo
+		platforms    []string
+		repositories int
+		codeLines    int
+		duplicates   []AggregateDuplicate
+	}{
+		{
+			name: "same commit",
+			sources: [][]testRepo{
+				{repo("PRJ", "api", "abc", go100)},
+				{repo("MIRROR", "api-mirror", "abc", go100)},
+			},
+			platforms:    []string{"BitBucket", "Github"},
+			repositories: 1,
+			codeLines:    100,
+			duplicates:   []AggregateDuplicate{{Repository: "Github/acme-1/MIRROR/api-mirror", MirrorOf: "BitBucket/acme-0/PRJ/api"}},
+		},
+		{
+			name: "same name and counts without commit",
+			sources: [][]testRepo{
+				{repo("PRJ", "Web", "", js50)},
+				{repo("OTHER", "web", "", js50)},
+			},
+			platforms:    []string{"BitBucket", "Github"},
+			repositories: 1,
+			codeLines:    50,
+			duplicates:   []AggregateDuplicate{{Repository: "Github/acme-1/OTHER/web", MirrorOf: "BitBucket/acme-0/PRJ/Web"}},
+		},
+		{
+			name: "same name with other counts",
+			sources: [][]testRepo{
+				{repo("PRJ", "web", "", js50)},
+				{repo("PRJ", "web", "", map[string]int{"JavaScript": 60})},
+			},
+			platforms:    []string{"BitBucket", "Github"},
+			repositories: 2,
+			codeLines:    110,
+			duplicates:   []AggregateDuplicate{},
+		},
+		{
+			name: "same commit in one directory",
+			sources: [][]testRepo{
+				{repo("PRJ", "api", "abc", go100), repo("PRJ", "fork", "abc", go100)},
+			},
+			platforms:    []string{"BitBucket"},
+			repositories: 2,
+			codeLines:    200,
+			duplicates:   []AggregateDuplicate{},
+		},
+		{
+			name: "first directory is kept",
+			sources: [][]testRepo{
+				{repo("PRJ", "api", "abc", go100)},
+				{repo("PRJ", "api", "abc", go100), repo("PRJ", "web", "def", js50)},
+				{repo("PRJ", "api", "abc", go100)},
+			},
+			platforms:    []string{"BitBucket", "Github", "Gitlab"},
+			repositories: 2,
+			codeLines:    150,
+			duplicates: []AggregateDuplicate{
+				{Repository: "Github/acme-1/PRJ/api", MirrorOf: "BitBucket/acme-0/PRJ/api"},
+				{Repository: "Gitlab/acme-2/PRJ/api", MirrorOf: "BitBucket/acme-0/PRJ/api"},
+			},
+		},
+	}
+
+	platforms := []string{"BitBucket", "Github", "Gitlab"}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var directories []string
+			for i, repos := range tt.sources {
+				directories = append(directories, writeSource(t, platforms[i], "acme-"+strconv.Itoa(i), repos...))
+			}
+			output := t.TempDir()
+
+			aggregate, err := Aggregate(output, directories)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if aggregate.Repositories != tt.repositories || aggregate.CodeLines != tt.codeLines {
+				t.Errorf("%d repositories and %d code lines, want %d and %d", aggregate.Repositories, aggregate.CodeLines, tt.repositories, tt.codeLines)
+			}
+			if !reflect.DeepEqual(aggregate.Duplicates, tt.duplicates) {
+				t.Errorf("duplicates %+v, want %+v", aggregate.Duplicates, tt.duplicates)
+			}
+			var names []string
+			for _, p := range aggregate.Platforms {
+				names = append(names, p.Platform)
+			}
+			if !reflect.DeepEqual(names, tt.platforms) {
+				t.Errorf("platforms %v, want %v", names, tt.platforms)
+			}
+
+			// The merged directory is read like the results of a run
+			repos, err := LoadRepos(output)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(repos) != tt.repositories {
+				t.Errorf("%d repositories in %s, want %d", len(repos), output, tt.repositories)
+			}
+			if _, err := os.Stat(filepath.Join(output, AggregateFile)); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+// The repositories of the same project and name in several organizations are all kept
+func TestAggregateSameNames(t *testing.T) {
+	first := writeSource(t, "Github", "acme", repo("PRJ", "api", "abc", map[string]int{"Go": 100}))
+	second := writeSource(t, "Github", "globex", repo("PRJ", "api", "def", map[string]int{"Go": 200}))
+	output := t.TempDir()
+
+	if _, err := Aggregate(output, []string{first, second}); err != nil {
+		t.Fatal(err)
+	}
+
+	repos, err := LoadRepos(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names, files []string
+	for _, repo := range repos {
+		names = append(names, HistoryRepoName(repo.Project, repo.Repository))
+		files = append(files, repo.ResultFile)
+	}
+	sort.Strings(names)
+	sort.Strings(files)
+
+	if want := []string{"Github/globex/PRJ/api", "PRJ/api"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("repositories %v, want %v", names, want)
+	}
+	if want := []string{"Result_Github_globex_PRJ_api_main.json", "Result_PRJ_api_main.json"}; !reflect.DeepEqual(files, want) {
+		t.Errorf("result files %v, want %v", files, want)
+	}
+}
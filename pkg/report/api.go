@@ -0,0 +1,473 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultPerPage = 50
+	maxPerPage     = 500
+)
+
+// Run of the history of an organization
+type RunRecord struct {
+	ID           int64     `json:"id"`
+	Time         time.Time `json:"time"`
+	Platform     string    `json:"platform"`
+	Organization string    `json:"organization"`
+	Repositories int       `json:"repositories"`
+	CodeLines    int       `json:"code_lines"`
+}
+
+// Read-only JSON API on a results directory, which is read again on every request
+// so that the latest results are returned
+type API struct {
+	Directory string
+	// History of the runs, only the last run of the directory is listed when nil
+	Runs func() ([]RunRecord, error)
+	// Growth of an organization over its runs, /api/history is not found when nil
+	History func(organization, by string) (*History, error)
+}
+
+type apiSummary struct {
+	Organization      string   `json:"organization"`
+	Platform          string   `json:"platform"`
+	Repositories      int      `json:"repositories"`
+	Languages         int      `json:"languages"`
+	TotalFiles        int      `json:"total_files"`
+	TotalLines        int      `json:"total_lines"`
+	TotalCodeLines    int      `json:"total_code_lines"`
+	LargestRepository string   `json:"largest_repository"`
+	LastRun           *RunInfo `json:"last_run,omitempty"`
+}
+
+type apiLanguage struct {
+	Language   string  `json:"language"`
+	Files      int     `json:"files"`
+	Lines      int     `json:"lines"`
+	BlankLines int     `json:"blank_lines"`
+	Comments   int     `json:"comments"`
+	CodeLines  int     `json:"code_lines"`
+	Percentage float64 `json:"percentage"`
+}
+
+type apiRepo struct {
+	Project    string        `json:"project"`
+	Repository string        `json:"repository"`
+	Branch     string        `json:"branch"`
+	Commit     string        `json:"commit,omitempty"`
+	Files      int           `json:"files"`
+	Lines      int           `json:"lines"`
+	BlankLines int           `json:"blank_lines"`
+	Comments   int           `json:"comments"`
+	CodeLines  int           `json:"code_lines"`
+	Languages  []apiLanguage `json:"languages,omitempty"`
+}
+
+type apiProject struct {
+	Project                    string        `json:"project"`
+	Repositories               int           `json:"repositories"`
+	CodeLines                  int           `json:"code_lines"`
+	LargestRepository          string        `json:"largest_repository"`
+	LargestRepositoryCodeLines int           `json:"largest_repository_code_lines"`
+	Languages                  []apiLanguage `json:"languages"`
+}
+
+type apiPage struct {
+	Total   int         `json:"total"`
+	Page    int         `json:"page"`
+	PerPage int         `json:"per_page"`
+	Items   interface{} `json:"items"`
+}
+
+// Query filters of the lists
+type apiFilter struct {
+	languages map[string]bool
+	project   string
+	minLOC    int
+	page      int
+	perPage   int
+}
+
+// Register the endpoints of the API on mux
+func (a *API) Register(mux *http.ServeMux) {
+	mux.HandleFunc("/api/summary", a.get(a.summary))
+	mux.HandleFunc("/api/languages", a.get(a.languages))
+	mux.HandleFunc("/api/repos", a.get(a.repos))
+	mux.HandleFunc("/api/repos/", a.get(a.repo))
+	mux.HandleFunc("/api/projects", a.get(a.projects))
+	mux.HandleFunc("/api/runs", a.get(a.runs))
+	mux.HandleFunc("/api/history", a.get(a.history))
+}
+
+// Handler of a GET endpoint returning a JSON value
+func (a *API) get(endpoint func(r *http.Request) (interface{}, int, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+			return
+		}
+
+		value, status, err := endpoint(r)
+		if err != nil {
+			writeJSON(w, status, map[string]string{"error": err.Error()})
+			return
+		}
+		writeJSON(w, http.StatusOK, value)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(value)
+}
+
+func parseFilter(r *http.Request) (apiFilter, error) {
+	q := r.URL.Query()
+	f := apiFilter{project: q.Get("project"), page: 1, perPage: defaultPerPage}
+
+	if value := q.Get("language"); value != "" {
+		f.languages = make(map[string]bool)
+		for _, lang := range strings.Split(value, ",") {
+			f.languages[strings.ToLower(strings.TrimSpace(lang))] = true
+		}
+	}
+
+	ints := []struct {
+		name  string
+		value *int
+		min   int
+	}{
+		{"min_loc", &f.minLOC, 0},
+		{"page", &f.page, 1},
+		{"per_page", &f.perPage, 1},
+	}
+	for _, i := range ints {
+		value := q.Get(i.name)
+		if value == "" {
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n < i.min {
+			return f, fmt.Errorf("invalid %s value '%s'", i.name, value)
+		}
+		*i.value = n
+	}
+	if f.perPage > maxPerPage {
+		f.perPage = maxPerPage
+	}
+
+	return f, nil
+}
+
+func (f apiFilter) language(name string) bool {
+	return f.languages == nil || f.languages[strings.ToLower(name)]
+}
+
+// Page of a list of n items, returns the bounds of the page
+func (f apiFilter) bounds(n int) (int, int) {
+	// A page after the end of the list is empty, its start is not computed so that it cannot overflow
+	if f.page-1 > n/f.perPage {
+		return n, n
+	}
+	start := (f.page - 1) * f.perPage
+	if start > n {
+		start = n
+	}
+	end := start + f.perPage
+	if end > n {
+		end = n
+	}
+	return start, end
+}
+
+func toAPILanguage(l LanguageResult) apiLanguage {
+	return apiLanguage{
+		Language:   l.Language,
+		Files:      l.Files,
+		Lines:      l.Lines,
+		BlankLines: l.BlankLines,
+		Comments:   l.Comments,
+		CodeLines:  l.CodeLines,
+	}
+}
+
+func toAPIRepo(repo RepoData, withLanguages bool) apiRepo {
+	r := apiRepo{
+		Project:    repo.Project,
+		Repository: repo.Repository,
+		Branch:     repo.Branch,
+		Commit:     repo.Commit,
+		Files:      repo.TotalFiles,
+		Lines:      repo.TotalLines,
+		BlankLines: repo.TotalBlankLines,
+		Comments:   repo.TotalComments,
+		CodeLines:  repo.TotalCodeLines,
+	}
+	if withLanguages {
+		for _, l := range repo.Results {
+			lang := toAPILanguage(l)
+			if repo.TotalCodeLines > 0 {
+				lang.Percentage = float64(l.CodeLines) / float64(repo.TotalCodeLines) * 100
+			}
+			r.Languages = append(r.Languages, lang)
+		}
+	}
+	return r
+}
+
+// Totals by language of the repositories, largest first
+func sumLanguages(repos []RepoData) []apiLanguage {
+	byName := make(map[string]*apiLanguage)
+	total := 0
+	for _, repo := range repos {
+		for _, l := range repo.Results {
+			lang, ok := byName[l.Language]
+			if !ok {
+				lang = &apiLanguage{Language: l.Language}
+				byName[l.Language] = lang
+			}
+			lang.Files += l.Files
+			lang.Lines += l.Lines
+			lang.BlankLines += l.BlankLines
+			lang.Comments += l.Comments
+			lang.CodeLines += l.CodeLines
+			total += l.CodeLines
+		}
+	}
+
+	languages := make([]apiLanguage, 0, len(byName))
+	for _, lang := range byName {
+		if total > 0 {
+			lang.Percentage = float64(lang.CodeLines) / float64(total) * 100
+		}
+		languages = append(languages, *lang)
+	}
+	sort.Slice(languages, func(i, j int) bool {
+		if languages[i].CodeLines != languages[j].CodeLines {
+			return languages[i].CodeLines > languages[j].CodeLines
+		}
+		return languages[i].Language < languages[j].Language
+	})
+	return languages
+}
+
+func (a *API) summary(r *http.Request) (interface{}, int, error) {
+	Ginfo, err := LoadGlobalReport(a.Directory)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	repos, err := LoadRepos(a.Directory)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	run, err := LoadRunInfo(a.Directory)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	s := apiSummary{
+		Organization:      Ginfo.Organization,
+		Platform:          Ginfo.DevOpsPlatform,
+		Repositories:      len(repos),
+		Languages:         len(sumLanguages(repos)),
+		LargestRepository: Ginfo.LargestRepository,
+	}
+	for _, repo := range repos {
+		s.TotalFiles += repo.TotalFiles
+		s.TotalLines += repo.TotalLines
+		s.TotalCodeLines += repo.TotalCodeLines
+	}
+	if !run.Start.IsZero() {
+		s.LastRun = &run
+	}
+
+	return s, http.StatusOK, nil
+}
+
+func (a *API) projects(r *http.Request) (interface{}, int, error) {
+	f, err := parseFilter(r)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	repos, err := LoadRepos(a.Directory)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	byProject := make(map[string][]RepoData)
+	for _, repo := range repos {
+		byProject[repo.Project] = append(byProject[repo.Project], repo)
+	}
+
+	projects := []apiProject{}
+	for _, p := range Projects(repos) {
+		if p.CodeLines < f.minLOC {
+			continue
+		}
+		projects = append(projects, apiProject{
+			Project:                    p.Project,
+			Repositories:               p.NumberRepos,
+			CodeLines:                  p.CodeLines,
+			LargestRepository:          p.LargestRepository,
+			LargestRepositoryCodeLines: p.CodeLinesLargestRepo,
+			Languages:                  sumLanguages(byProject[p.Project]),
+		})
+	}
+
+	start, end := f.bounds(len(projects))
+	return apiPage{Total: len(projects), Page: f.page, PerPage: f.perPage, Items: projects[start:end]}, http.StatusOK, nil
+}
+
+func (a *API) languages(r *http.Request) (interface{}, int, error) {
+	f, err := parseFilter(r)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	repos, err := LoadRepos(a.Directory)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	var selected []RepoData
+	for _, repo := range repos {
+		if f.project == "" || repo.Project == f.project {
+			selected = append(selected, repo)
+		}
+	}
+
+	languages := []apiLanguage{}
+	for _, lang := range sumLanguages(selected) {
+		if f.language(lang.Language) && lang.CodeLines >= f.minLOC {
+			languages = append(languages, lang)
+		}
+	}
+
+	start, end := f.bounds(len(languages))
+	return apiPage{Total: len(languages), Page: f.page, PerPage: f.perPage, Items: languages[start:end]}, http.StatusOK, nil
+}
+
+func (a *API) repos(r *http.Request) (interface{}, int, error) {
+	f, err := parseFilter(r)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	repos, err := LoadRepos(a.Directory)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	SortReposByCodeLines(repos)
+
+	items := []apiRepo{}
+	for _, repo := range repos {
+		if f.project != "" && repo.Project != f.project {
+			continue
+		}
+		if repo.TotalCodeLines < f.minLOC {
+			continue
+		}
+		if f.languages != nil {
+			found := false
+			for _, l := range repo.Results {
+				found = found || f.language(l.Language)
+			}
+			if !found {
+				continue
+			}
+		}
+		items = append(items, toAPIRepo(repo, false))
+	}
+
+	start, end := f.bounds(len(items))
+	return apiPage{Total: len(items), Page: f.page, PerPage: f.perPage, Items: items[start:end]}, http.StatusOK, nil
+}
+
+// /api/repos/{project}/{repo} : the project may contain "/" (Gitlab groups), "-" is an empty project
+func (a *API) repo(r *http.Request) (interface{}, int, error) {
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/repos/"), "/")
+	i := strings.LastIndex(path, "/")
+	if i <= 0 || i == len(path)-1 {
+		return nil, http.StatusNotFound, fmt.Errorf("use /api/repos/{project}/{repo}")
+	}
+	project, name := path[:i], path[i+1:]
+	if project == "-" {
+		project = ""
+	}
+
+	repos, err := LoadRepos(a.Directory)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	for _, repo := range repos {
+		if repo.Project == project && repo.Repository == name {
+			return toAPIRepo(repo, true), http.StatusOK, nil
+		}
+	}
+
+	return nil, http.StatusNotFound, fmt.Errorf("repository %s/%s not found", project, name)
+}
+
+func (a *API) runs(r *http.Request) (interface{}, int, error) {
+	f, err := parseFilter(r)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	runs := []RunRecord{}
+	if a.Runs != nil {
+		if runs, err = a.Runs(); err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+	} else {
+		Ginfo, err := LoadGlobalReport(a.Directory)
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		info, err := LoadRunInfo(a.Directory)
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		repos, err := LoadRepos(a.Directory)
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		run := RunRecord{Time: info.Start, Platform: Ginfo.DevOpsPlatform, Organization: Ginfo.Organization, Repositories: len(repos)}
+		for _, repo := range repos {
+			run.CodeLines += repo.TotalCodeLines
+		}
+		runs = append(runs, run)
+	}
+
+	start, end := f.bounds(len(runs))
+	return apiPage{Total: len(runs), Page: f.page, PerPage: f.perPage, Items: runs[start:end]}, http.StatusOK, nil
+}
+
+func (a *API) history(r *http.Request) (interface{}, int, error) {
+	if a.History == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("no history of the runs, start the server with a database")
+	}
+
+	q := r.URL.Query()
+	by := q.Get("by")
+	switch by {
+	case "", HistoryProject, HistoryRepository, HistoryLanguage:
+	default:
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid by %q, expected %s, %s or %s", by, HistoryProject, HistoryRepository, HistoryLanguage)
+	}
+
+	history, err := a.History(q.Get("organization"), by)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return history, http.StatusOK, nil
+}
@@ -0,0 +1,194 @@
+package report
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// Results directory of three repositories in two projects
+func apiServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	dir := t.TempDir()
+	writeResult(t, dir, "Result_PRJ_api_main.json", &ResultMetadata{Project: "PRJ", Repository: "api", Branch: "main"}, map[string]int{"Go": 300, "Shell": 20})
+	writeResult(t, dir, "Result_PRJ_web_main.json", &ResultMetadata{Project: "PRJ", Repository: "web", Branch: "main"}, map[string]int{"JavaScript": 200})
+	writeResult(t, dir, "Result_OPS_tools_main.json", &ResultMetadata{Project: "OPS", Repository: "tools", Branch: "main"}, map[string]int{"Go": 50})
+
+	mux := http.NewServeMux()
+	(&API{Directory: dir}).Register(mux)
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+type testPage struct {
+	Total   int               `json:"total"`
+	Page    int               `json:"page"`
+	PerPage int               `json:"per_page"`
+	Items   []json.RawMessage `json:"items"`
+}
+
+// Name of the items of a page, read from the given field
+func (p testPage) names(t *testing.T, field string) []string {
+	t.Helper()
+	names := []string{}
+	for _, item := range p.Items {
+		var fields map[string]interface{}
+		if err := json.Unmarshal(item, &fields); err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, fields[field].(string))
+	}
+	return names
+}
+
+func getPage(t *testing.T, server *httptest.Server, path string) (testPage, int) {
+	t.Helper()
+	var page testPage
+	resp, err := http.Get(server.URL + path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return page, resp.StatusCode
+}
+
+func TestAPIRepos(t *testing.T) {
+	server := apiServer(t)
+
+	tests := []struct {
+		name   string
+		path   string
+		status int
+		total  int
+		repos  []string
+	}{
+		{"all", "/api/repos", http.StatusOK, 3, []string{"api", "web", "tools"}},
+		{"project", "/api/repos?project=PRJ", http.StatusOK, 2, []string{"api", "web"}},
+		{"language", "/api/repos?language=go", http.StatusOK, 2, []string{"api", "tools"}},
+		{"languages", "/api/repos?language=Shell,%20JavaScript", http.StatusOK, 2, []string{"api", "web"}},
+		{"min_loc", "/api/repos?min_loc=200", http.StatusOK, 2, []string{"api", "web"}},
+		{"first page", "/api/repos?per_page=2", http.StatusOK, 3, []string{"api", "web"}},
+		{"last page", "/api/repos?per_page=2&page=2", http.StatusOK, 3, []string{"tools"}},
+		{"after the end", "/api/repos?per_page=2&page=3", http.StatusOK, 3, []string{}},
+		{"page overflow", "/api/repos?per_page=500&page=9223372036854775807", http.StatusOK, 3, []string{}},
+		{"invalid page", "/api/repos?page=0", http.StatusBadRequest, 0, nil},
+		{"invalid per_page", "/api/repos?per_page=x", http.StatusBadRequest, 0, nil},
+		{"invalid min_loc", "/api/repos?min_loc=-1", http.StatusBadRequest, 0, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, status := getPage(t, server, tt.path)
+			if status != tt.status {
+				t.Fatalf("status %d, want %d", status, tt.status)
+			}
+			if status != http.StatusOK {
+				return
+			}
+			if page.Total != tt.total {
+				t.Errorf("total %d, want %d", page.Total, tt.total)
+			}
+			if repos := page.names(t, "repository"); !reflect.DeepEqual(repos, tt.repos) {
+				t.Errorf("repositories %v, want %v", repos, tt.repos)
+			}
+		})
+	}
+}
+
+func TestAPIPerPage(t *testing.T) {
+	server := apiServer(t)
+
+	tests := []struct {
+		path    string
+		perPage int
+	}{
+		{"/api/repos", defaultPerPage},
+		{"/api/repos?per_page=1", 1},
+		{"/api/repos?per_page=100000", maxPerPage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			page, status := getPage(t, server, tt.path)
+			if status != http.StatusOK {
+				t.Fatalf("status %d", status)
+			}
+			if page.PerPage != tt.perPage {
+				t.Errorf("per_page %d, want %d", page.PerPage, tt.perPage)
+			}
+		})
+	}
+}
+
+func TestAPILanguagesAndProjects(t *testing.T) {
+	server := apiServer(t)
+
+	tests := []struct {
+		name  string
+		path  string
+		field string
+		items []string
+	}{
+		{"languages", "/api/languages", "language", []string{"Go", "JavaScript", "Shell"}},
+		{"languages of a project", "/api/languages?project=OPS", "language", []string{"Go"}},
+		{"languages filtered", "/api/languages?language=shell,javascript", "language", []string{"JavaScript", "Shell"}},
+		{"languages min_loc", "/api/languages?min_loc=100", "language", []string{"Go", "JavaScript"}},
+		{"projects", "/api/projects", "project", []string{"PRJ", "OPS"}},
+		{"projects min_loc", "/api/projects?min_loc=100", "project", []string{"PRJ"}},
+		{"projects page", "/api/projects?per_page=1&page=2", "project", []string{"OPS"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, status := getPage(t, server, tt.path)
+			if status != http.StatusOK {
+				t.Fatalf("status %d", status)
+			}
+			if items := page.names(t, tt.field); !reflect.DeepEqual(items, tt.items) {
+				t.Errorf("items %v, want %v", items, tt.items)
+			}
+		})
+	}
+}
+
+func TestAPIRepo(t *testing.T) {
+	server := apiServer(t)
+
+	tests := []struct {
+		path   string
+		method string
+		status int
+	}{
+		{"/api/repos/PRJ/api", http.MethodGet, http.StatusOK},
+		{"/api/repos/PRJ/api", http.MethodHead, http.StatusOK},
+		{"/api/repos/PRJ/api", http.MethodPost, http.StatusMethodNotAllowed},
+		{"/api/repos/PRJ/missing", http.MethodGet, http.StatusNotFound},
+		{"/api/repos/api", http.MethodGet, http.StatusNotFound},
+		{"/api/history", http.MethodGet, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, server.URL+tt.path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tt.status {
+				t.Errorf("status %d, want %d", resp.StatusCode, tt.status)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package report
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+)
+
+// Credentials of the web server, it is open when they are all empty.
+// A request is accepted with either the basic auth user and password or the bearer token.
+type Auth struct {
+	User     string
+	Password string
+	Token    string
+}
+
+func (a Auth) Enabled() bool {
+	return a.User != "" || a.Token != ""
+}
+
+func equal(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+func (a Auth) allowed(r *http.Request) bool {
+	if a.Token != "" {
+		if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok && equal(token, a.Token) {
+			return true
+		}
+	}
+	if a.User != "" {
+		if user, password, ok := r.BasicAuth(); ok && equal(user, a.User) && equal(password, a.Password) {
+			return true
+		}
+	}
+	return false
+}
+
+// Wrap a handler to reject the requests without valid credentials
+func (a Auth) Handler(next http.Handler) http.Handler {
+	if !a.Enabled() {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !a.allowed(r) {
+			if a.User != "" {
+				w.Header().Set("WWW-Authenticate", `Basic realm="GoLC", charset="UTF-8"`)
+			}
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
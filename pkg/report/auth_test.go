@@ -0,0 +1,72 @@
+package report
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAuthHandler(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	basic := Auth{User: "admin", Password: "secret"}
+	token := Auth{Token: "t0ken"}
+	both := Auth{User: "admin", Password: "secret", Token: "t0ken"}
+
+	tests := []struct {
+		name      string
+		auth      Auth
+		user      string
+		password  string
+		bearer    string
+		status    int
+		challenge bool
+	}{
+		{"open", Auth{}, "", "", "", http.StatusOK, false},
+		{"basic", basic, "admin", "secret", "", http.StatusOK, false},
+		{"basic wrong password", basic, "admin", "wrong", "", http.StatusUnauthorized, true},
+		{"basic wrong user", basic, "root", "secret", "", http.StatusUnauthorized, true},
+		{"basic missing", basic, "", "", "", http.StatusUnauthorized, true},
+		{"basic with a token", basic, "", "", "t0ken", http.StatusUnauthorized, true},
+		{"token", token, "", "", "t0ken", http.StatusOK, false},
+		{"token wrong", token, "", "", "other", http.StatusUnauthorized, false},
+		{"token missing", token, "", "", "", http.StatusUnauthorized, false},
+		{"both with basic", both, "admin", "secret", "", http.StatusOK, false},
+		{"both with token", both, "", "", "t0ken", http.StatusOK, false},
+		{"both wrong", both, "admin", "wrong", "other", http.StatusUnauthorized, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.auth.Handler(ok))
+			defer server.Close()
+
+			req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.user != "" {
+				req.SetBasicAuth(tt.user, tt.password)
+			}
+			if tt.bearer != "" {
+				// The bearer token replaces the basic auth header
+				req.Header.Set("Authorization", "Bearer "+tt.bearer)
+			}
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != tt.status {
+				t.Errorf("status %d, want %d", resp.StatusCode, tt.status)
+			}
+			if challenge := resp.Header.Get("WWW-Authenticate") != ""; challenge != tt.challenge {
+				t.Errorf("WWW-Authenticate header %v, want %v", challenge, tt.challenge)
+			}
+		})
+	}
+}
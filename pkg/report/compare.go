@@ -0,0 +1,61 @@
+package report
+
+import (
+	"fmt"
+
+	"github.com/colussim/GoLC/pkg/diff"
+)
+
+// Compare the repositories of two results directories, read like the global report from the
+// journal or else from the metadata of their results, so that stale results are not compared
+func CompareDirs(oldDir, newDir string) (*diff.Report, error) {
+	oldResults, err := diffResults(oldDir)
+	if err != nil {
+		return nil, err
+	}
+	newResults, err := diffResults(newDir)
+	if err != nil {
+		return nil, err
+	}
+
+	return diff.CompareResults(oldResults, newResults), nil
+}
+
+// Code lines of the repositories of a results directory by project/repository, so that a change
+// of branch is not a removed and an added repository. Several branches of a repository are
+// named repository@branch, and the same branch twice is an error.
+func diffResults(directory string) (map[string]diff.Result, error) {
+	repos, err := LoadRepos(directory)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, len(repos))
+	count := make(map[string]int)
+	for i, repo := range repos {
+		names[i] = repo.Repository
+		if repo.Project != "" {
+			names[i] = repo.Project + "/" + repo.Repository
+		}
+		count[names[i]]++
+	}
+
+	results := make(map[string]diff.Result, len(repos))
+	for i, repo := range repos {
+		name := names[i]
+		if count[name] > 1 {
+			name += "@" + repo.Branch
+		}
+		if _, ok := results[name]; ok {
+			return nil, fmt.Errorf("%s: the repository %s is in %s and in another result with the same branch", directory, name, repo.ResultFile)
+		}
+
+		languages := make(map[string]int)
+		for _, lang := range repo.Results {
+			languages[lang.Language] += lang.CodeLines
+		}
+		results[name] = diff.Result{TotalCodeLines: repo.TotalCodeLines, Languages: languages}
+	}
+
+	return results, nil
+}
@@ -0,0 +1,118 @@
+package report
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/colussim/GoLC/pkg/diff"
+	"github.com/colussim/GoLC/pkg/journal"
+)
+
+// Write a json report with its metadata in a results directory
+func writeResult(t *testing.T, dir, name string, metadata *ResultMetadata, codeLines map[string]int) {
+	t.Helper()
+	result := resultReport{Metadata: metadata}
+	for language, lines := range codeLines {
+		result.Results = append(result.Results, LanguageResult{Language: language, CodeLines: lines})
+		result.TotalCodeLines += lines
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Record the json reports of a results directory in its journal
+func writeJournal(t *testing.T, dir string, entries ...journal.Entry) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Join(dir, "config"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	j, err := journal.Open(filepath.Join(dir, journalPath), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer j.Close()
+	for _, entry := range entries {
+		if err := j.Record(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func repoChanges(report *diff.Report) map[string]diff.Change {
+	changes := make(map[string]diff.Change)
+	for _, repo := range report.Repos {
+		changes[repo.Name] = repo.Change
+	}
+	return changes
+}
+
+func TestCompareDirs(t *testing.T) {
+	api := &ResultMetadata{Project: "PRJ", Repository: "api", Branch: "main"}
+	web := &ResultMetadata{Project: "PRJ", Repository: "web", Branch: "main"}
+
+	oldDir := t.TempDir()
+	writeResult(t, oldDir, "Result_PRJ_api_main.json", api, map[string]int{"Go": 100})
+	writeResult(t, oldDir, "Result_PRJ_web_main.json", web, map[string]int{"JavaScript": 50})
+
+	// The branch of api changed, web was removed and its stale result is not in the journal
+	newDir := t.TempDir()
+	apiDevelop := &ResultMetadata{Project: "PRJ", Repository: "api", Branch: "develop"}
+	writeResult(t, newDir, "Result_PRJ_api_develop.json", apiDevelop, map[string]int{"Go": 120})
+	writeResult(t, newDir, "Result_PRJ_web_main.json", web, map[string]int{"JavaScript": 50})
+	writeJournal(t, newDir, apiDevelop.Entry("Result_PRJ_api_develop.json"))
+
+	report, err := CompareDirs(oldDir, newDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]diff.Change{
+		"PRJ/api": {Name: "PRJ/api", Status: diff.Changed, OldLines: 100, NewLines: 120, Delta: 20},
+		"PRJ/web": {Name: "PRJ/web", Status: diff.Removed, OldLines: 50, Delta: -50},
+	}
+	changes := repoChanges(report)
+	if len(changes) != len(want) {
+		t.Fatalf("changes %+v, want %+v", changes, want)
+	}
+	for name, change := range want {
+		if changes[name] != change {
+			t.Errorf("%s: %+v, want %+v", name, changes[name], change)
+		}
+	}
+	if report.Delta != -30 {
+		t.Errorf("Delta = %d, want -30", report.Delta)
+	}
+}
+
+func TestCompareDirsBranches(t *testing.T) {
+	main := &ResultMetadata{Project: "PRJ", Repository: "api", Branch: "main"}
+	develop := &ResultMetadata{Project: "PRJ", Repository: "api", Branch: "develop"}
+
+	// Without journal, the branches of a repository are compared by branch
+	oldDir, newDir := t.TempDir(), t.TempDir()
+	for _, dir := range []string{oldDir, newDir} {
+		writeResult(t, dir, "Result_PRJ_api_main.json", main, map[string]int{"Go": 10})
+		writeResult(t, dir, "Result_PRJ_api_develop.json", develop, map[string]int{"Go": 20})
+	}
+	report, err := CompareDirs(oldDir, newDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	changes := repoChanges(report)
+	if changes["PRJ/api@main"].Status != diff.Unchanged || changes["PRJ/api@develop"].Status != diff.Unchanged {
+		t.Errorf("changes %+v, want PRJ/api@main and PRJ/api@develop unchanged", changes)
+	}
+
+	// A copy of a result is not compared in an arbitrary order
+	writeResult(t, newDir, "Result_copy.json", main, map[string]int{"Go": 30})
+	if _, err := CompareDirs(oldDir, newDir); err == nil {
+		t.Error("no error for a repository twice with the same branch")
+	}
+}
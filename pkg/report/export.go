@@ -0,0 +1,64 @@
+package report
+
+import (
+	"io"
+	"strconv"
+
+	"github.com/colussim/GoLC/pkg/reporter/csv"
+)
+
+// Header of the organization sheet
+var SheetHeader = []string{"Project", "Repository", "Branch", "Language", "Files", "Lines", "BlankLines", "Comments", "CodeLines"}
+
+// Write one row per repository and language of a results directory.
+// comma is the field separator, a last row with the totals is added when totals is true.
+func WriteSheet(w io.Writer, directory string, comma rune, totals bool) error {
+	repos, err := LoadRepos(directory)
+	if err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(w, comma)
+	if err := writer.Write(SheetHeader); err != nil {
+		return err
+	}
+
+	var total LanguageResult
+	for _, repo := range repos {
+		for _, r := range repo.Results {
+			err := writer.Write([]string{
+				repo.Project,
+				repo.Repository,
+				repo.Branch,
+				r.Language,
+				strconv.Itoa(r.Files),
+				strconv.Itoa(r.Lines),
+				strconv.Itoa(r.BlankLines),
+				strconv.Itoa(r.Comments),
+				strconv.Itoa(r.CodeLines),
+			})
+			if err != nil {
+				return err
+			}
+			total.Files += r.Files
+			total.Lines += r.Lines
+			total.BlankLines += r.BlankLines
+			total.Comments += r.Comments
+			total.CodeLines += r.CodeLines
+		}
+	}
+
+	if totals {
+		writer.Write([]string{
+			"Total", "", "", "",
+			strconv.Itoa(total.Files),
+			strconv.Itoa(total.Lines),
+			strconv.Itoa(total.BlankLines),
+			strconv.Itoa(total.Comments),
+			strconv.Itoa(total.CodeLines),
+		})
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,38 @@
+package report
+
+// Levels of detail of a history
+const (
+	HistoryProject    = "project"
+	HistoryRepository = "repo"
+	HistoryLanguage   = "language"
+)
+
+// Code lines of a project, repository or language at each run of a history
+type HistorySerie struct {
+	Name      string `json:"name"`
+	CodeLines []int  `json:"code_lines"` // One value by run, 0 when it was not analysed
+}
+
+// Repositories added and removed since the previous run of the organization
+type RunChanges struct {
+	RunID   int64    `json:"run_id"`
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+}
+
+// Growth of an organization over its runs, the oldest first
+type History struct {
+	Organization string         `json:"organization"`
+	By           string         `json:"by,omitempty"`
+	Runs         []RunRecord    `json:"runs"`
+	Series       []HistorySerie `json:"series"`
+	Changes      []RunChanges   `json:"changes"` // One by run after the first
+}
+
+// Name of a repository in a history
+func HistoryRepoName(project, repository string) string {
+	if project == "" {
+		return repository
+	}
+	return project + "/" + repository
+}
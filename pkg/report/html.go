@@ -0,0 +1,53 @@
+package report
+
+import (
+	"encoding/base64"
+	"html/template"
+	"io"
+	"time"
+
+	"github.com/colussim/GoLC/dist"
+)
+
+// Data of the static HTML report
+type StaticData struct {
+	*PageData
+	Repos     []RepoData
+	Generated string
+	CSS       template.CSS
+	ChartJS   template.JS
+	Logo      template.URL
+}
+
+// Write the HTML report of a results directory as a single file, with its
+// styles, scripts and images inlined so that it can be read offline
+func WriteHTML(w io.Writer, data *PageData, repos []RepoData) error {
+	css, err := dist.FS.ReadFile("css/theme.min.css")
+	if err != nil {
+		return err
+	}
+	chartJS, err := dist.FS.ReadFile("vendors/chartjs/chart.js")
+	if err != nil {
+		return err
+	}
+	logo, err := dist.FS.ReadFile("img/Logo.png")
+	if err != nil {
+		return err
+	}
+
+	SortReposByCodeLines(repos)
+
+	tmpl, err := template.New("static").Parse(staticTemplate)
+	if err != nil {
+		return err
+	}
+
+	return tmpl.Execute(w, StaticData{
+		PageData:  data,
+		Repos:     repos,
+		Generated: time.Now().Format("2006-01-02 15:04"),
+		CSS:       template.CSS(css),
+		ChartJS:   template.JS(chartJS),
+		Logo:      template.URL("data:image/png;base64," + base64.StdEncoding.EncodeToString(logo)),
+	})
+}
@@ -0,0 +1,69 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/colussim/GoLC/pkg/diff"
+	"github.com/colussim/GoLC/pkg/reporter/markdown"
+	"github.com/colussim/GoLC/pkg/utils"
+)
+
+// Write the markdown summary of a results directory : the global results, the languages
+// and the top repositories, 0 for all. The changes since a baseline are added when it is not nil.
+func WriteMarkdown(w io.Writer, data *PageData, repos []RepoData, top int, baseline *diff.Report) error {
+	Ginfo := data.GlobalReport
+
+	fmt.Fprintf(w, "## GoLC results : %s\n\n", markdown.Escape(Ginfo.Organization))
+	fmt.Fprintf(w, "- Total lines of code : **%s**\n", Ginfo.TotalLinesOfCode)
+	fmt.Fprintf(w, "- Number of repositories analyzed : %d\n", Ginfo.NumberRepos)
+	fmt.Fprintf(w, "- Largest repository : %s (%s lines of code)\n\n", markdown.Escape(Ginfo.LargestRepository), Ginfo.LinesOfCodeLargestRepo)
+
+	var rows [][]string
+	for _, lang := range data.Languages {
+		rows = append(rows, []string{lang.Language, lang.CodeLinesF, fmt.Sprintf("%.2f %%", lang.Percentage)})
+	}
+	fmt.Fprint(w, "### Languages\n\n")
+	if err := markdown.WriteTable(w, []string{"Language", "Code lines", "Share"}, "lrr", rows); err != nil {
+		return err
+	}
+
+	SortReposByCodeLines(repos)
+	title := "### Repositories\n\n"
+	if top > 0 && len(repos) > top {
+		title = fmt.Sprintf("### Top %d of %d repositories\n\n", top, len(repos))
+		repos = repos[:top]
+	}
+	rows = nil
+	for _, repo := range repos {
+		rows = append(rows, []string{repo.Project, repo.Repository, markdown.Code(repo.Branch), strconv.Itoa(repo.TotalFiles), repo.CodeLinesF()})
+	}
+	fmt.Fprint(w, title)
+	if err := markdown.WriteTable(w, []string{"Project", "Repository", "Branch", "Files", "Code lines"}, "lllrr", rows); err != nil {
+		return err
+	}
+
+	if baseline == nil {
+		return nil
+	}
+
+	fmt.Fprint(w, "### Changes since the baseline\n\n")
+	fmt.Fprintf(w, "Code lines : %s → %s (**%s**)\n\n", utils.FormatCodeLines(float64(baseline.OldTotal)), utils.FormatCodeLines(float64(baseline.NewTotal)), signed(baseline.Delta))
+	rows = nil
+	for _, repo := range baseline.Repos {
+		if repo.Status == diff.Unchanged {
+			continue
+		}
+		rows = append(rows, []string{repo.Name, repo.Status, strconv.Itoa(repo.OldLines), strconv.Itoa(repo.NewLines), signed(repo.Delta)})
+	}
+	if len(rows) == 0 {
+		fmt.Fprint(w, "No repository changed.\n")
+		return nil
+	}
+	return markdown.WriteTable(w, []string{"Repository", "Status", "Old code lines", "New code lines", "Delta"}, "llrrr", rows)
+}
+
+func signed(n int) string {
+	return fmt.Sprintf("%+d", n)
+}
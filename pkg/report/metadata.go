@@ -0,0 +1,83 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/colussim/GoLC/pkg/journal"
+)
+
+// Repository of a json report, written with its results by the scan
+type ResultMetadata struct {
+	Platform     string    `json:"Platform"`
+	Organization string    `json:"Organization"`
+	Project      string    `json:"Project"`
+	Namespace    string    `json:"Namespace,omitempty"` // Gitlab : full path of the repository
+	Repository   string    `json:"Repository"`
+	Branch       string    `json:"Branch"`
+	Commit       string    `json:"Commit"`
+	ScanTime     time.Time `json:"ScanTime"`
+	Version      string    `json:"Version"` // Version of GoLC
+}
+
+// Json report of a repository with its metadata first
+type resultReport struct {
+	Metadata        *ResultMetadata  `json:"Metadata,omitempty"`
+	TotalFiles      int              `json:"TotalFiles,omitempty"`
+	TotalLines      int              `json:"TotalLines"`
+	TotalBlankLines int              `json:"TotalBlankLines"`
+	TotalComments   int              `json:"TotalComments"`
+	TotalCodeLines  int              `json:"TotalCodeLines"`
+	Results         []LanguageResult `json:"Results"`
+}
+
+// Journal entry of the metadata of a json report
+func (m ResultMetadata) Entry(resultFile string) journal.Entry {
+	return journal.Entry{
+		ProjectKey: m.Project,
+		Namespace:  m.Namespace,
+		RepoSlug:   m.Repository,
+		Branch:     m.Branch,
+		Commit:     m.Commit,
+		ResultFile: resultFile,
+		Time:       m.ScanTime,
+	}
+}
+
+// Add the metadata of a repository to its json report
+func WriteResultMetadata(path string, metadata ResultMetadata) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var result resultReport
+	if err := json.Unmarshal(data, &result); err != nil {
+		return fmt.Errorf("error parsing %s: %w", path, err)
+	}
+	result.Metadata = &metadata
+
+	data, err = json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// Read the metadata of a json report, nil for a report written before the metadata
+func ReadResultMetadata(path string) (*ResultMetadata, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Metadata *ResultMetadata `json:"Metadata"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("error parsing %s: %w", path, err)
+	}
+	return result.Metadata, nil
+}
@@ -0,0 +1,101 @@
+package report
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+
+	"github.com/colussim/GoLC/pkg/devops/inventory"
+	"github.com/colussim/GoLC/pkg/reporter/openmetrics"
+)
+
+// Write the metrics of the latest results of a directory, by repository and language,
+// with the operational gauges of the last run
+func WriteMetrics(w io.Writer, directory string) error {
+	Ginfo, err := LoadGlobalReport(directory)
+	if err != nil {
+		return err
+	}
+	repos, err := LoadRepos(directory)
+	if err != nil {
+		return err
+	}
+	run, err := LoadRunInfo(directory)
+	if err != nil {
+		return err
+	}
+
+	org := []openmetrics.Label{{Name: "platform", Value: Ginfo.DevOpsPlatform}, {Name: "org", Value: Ginfo.Organization}}
+
+	families := []struct {
+		name, help string
+		value      func(l LanguageResult) int
+	}{
+		{"golc_files", "Number of files", func(l LanguageResult) int { return l.Files }},
+		{"golc_lines", "Number of lines", func(l LanguageResult) int { return l.Lines }},
+		{"golc_blank_lines", "Number of blank lines", func(l LanguageResult) int { return l.BlankLines }},
+		{"golc_comment_lines", "Number of comment lines", func(l LanguageResult) int { return l.Comments }},
+		{"golc_code_lines", "Number of code lines", func(l LanguageResult) int { return l.CodeLines }},
+	}
+
+	total := 0
+	for _, repo := range repos {
+		total += repo.TotalCodeLines
+	}
+
+	for _, f := range families {
+		var samples []openmetrics.Sample
+		for _, repo := range repos {
+			for _, lang := range repo.Results {
+				labels := append(append([]openmetrics.Label{}, org...),
+					openmetrics.Label{Name: "project", Value: repo.Project},
+					openmetrics.Label{Name: "repo", Value: repo.Repository},
+					openmetrics.Label{Name: "branch", Value: repo.Branch},
+					openmetrics.Label{Name: "language", Value: lang.Language},
+				)
+				samples = append(samples, openmetrics.Sample{Labels: labels, Value: float64(f.value(lang))})
+			}
+		}
+		if err := openmetrics.WriteGauge(w, f.name, f.help, samples); err != nil {
+			return err
+		}
+	}
+
+	gauges := []struct {
+		name, help string
+		value      float64
+	}{
+		{"golc_org_code_lines", "Number of code lines of the organization", float64(total)},
+		{"golc_last_run_timestamp_seconds", "Start time of the last run", float64(run.Start.Unix())},
+		{"golc_last_run_duration_seconds", "Duration of the last run", run.Duration},
+		{"golc_last_run_repos_analyzed", "Number of repositories analyzed by the last run", float64(run.Analysed)},
+		{"golc_last_run_repos_failed", "Number of repositories whose analysis failed in the last run", float64(run.Failed)},
+		{"golc_last_run_repos_excluded", "Number of repositories excluded by the last run", float64(run.Statuses[inventory.Excluded])},
+		{"golc_last_run_repos_archived", "Number of archived repositories skipped by the last run", float64(run.Statuses[inventory.Archived])},
+	}
+	if run.Start.IsZero() {
+		// Results of a version without run summary
+		gauges = gauges[:1]
+	}
+
+	for _, g := range gauges {
+		if err := openmetrics.WriteGauge(w, g.name, g.help, []openmetrics.Sample{{Labels: org, Value: g.value}}); err != nil {
+			return err
+		}
+	}
+
+	return openmetrics.WriteEOF(w)
+}
+
+// Serve the metrics of the latest results of a directory, read again on every scrape
+func MetricsHandler(directory string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var buf bytes.Buffer
+		if err := WriteMetrics(&buf, directory); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", openmetrics.ContentType)
+		buf.WriteTo(w)
+	})
+}
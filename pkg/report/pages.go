@@ -0,0 +1,254 @@
+package report
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Repository of the list pages, with its share of the organization
+type repoPage struct {
+	RepoData
+	Path  string
+	Share float64
+}
+
+type reposPageData struct {
+	Organization string
+	Project      string // Only the repositories of the project are listed when it is set
+	Repos        []repoPage
+	Total        int
+}
+
+// Project of the project list, with its share of the organization
+type projectPage struct {
+	ProjectSummary
+	Share float64
+}
+
+type projectsPageData struct {
+	Organization string
+	Projects     []projectPage
+}
+
+type repoPageData struct {
+	Organization string
+	Repo         repoPage
+	Languages    []LanguageData
+}
+
+// Path of the page of a repository, "-" is an empty project
+func RepoPath(project, repository string) string {
+	if project == "" {
+		project = "-"
+	}
+	var segments []string
+	for _, s := range strings.Split(project, "/") {
+		segments = append(segments, url.PathEscape(s))
+	}
+	return "/repos/" + strings.Join(segments, "/") + "/" + url.PathEscape(repository)
+}
+
+// Register the repository list on /repos, filtered with ?project=, the repository pages on
+// /repos/{project}/{repo} and the project list on /projects.
+// The results of directory are read again on every request.
+func RegisterRepoPages(mux *http.ServeMux, directory string) {
+	list := template.Must(template.New("repos").Parse(reposTemplate))
+	detail := template.Must(template.New("repo").Parse(repoTemplate))
+	projects := template.Must(template.New("projects").Parse(projectsTemplate))
+
+	mux.HandleFunc("/repos", func(w http.ResponseWriter, r *http.Request) {
+		data, err := loadReposPage(directory)
+		if err != nil {
+			http.Error(w, "❌ "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if project := r.URL.Query().Get("project"); project != "" {
+			var repos []repoPage
+			for _, repo := range data.Repos {
+				if repo.Project == project {
+					repos = append(repos, repo)
+				}
+			}
+			data.Project, data.Repos = project, repos
+		}
+		if err := list.Execute(w, data); err != nil {
+			http.Error(w, "❌ Error executing HTML template", http.StatusInternalServerError)
+		}
+	})
+
+	mux.HandleFunc("/repos/", func(w http.ResponseWriter, r *http.Request) {
+		data, err := loadReposPage(directory)
+		if err != nil {
+			http.Error(w, "❌ "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		project, name := splitRepoPath(r.URL.Path)
+		for _, repo := range data.Repos {
+			if repo.Project != project || repo.Repository != name {
+				continue
+			}
+			page := repoPageData{Organization: data.Organization, Repo: repo}
+			for _, l := range repo.Results {
+				lang := LanguageData{Language: l.Language, CodeLines: l.CodeLines}
+				if repo.TotalCodeLines > 0 {
+					lang.Percentage = float64(l.CodeLines) / float64(repo.TotalCodeLines) * 100
+				}
+				lang.FormatCodeLines()
+				page.Languages = append(page.Languages, lang)
+			}
+			if err := detail.Execute(w, page); err != nil {
+				http.Error(w, "❌ Error executing HTML template", http.StatusInternalServerError)
+			}
+			return
+		}
+
+		http.NotFound(w, r)
+	})
+
+	mux.HandleFunc("/projects", func(w http.ResponseWriter, r *http.Request) {
+		data, err := loadReposPage(directory)
+		if err != nil {
+			http.Error(w, "❌ "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		page := projectsPageData{Organization: data.Organization, Projects: []projectPage{}}
+		repos := make([]RepoData, 0, len(data.Repos))
+		for _, repo := range data.Repos {
+			repos = append(repos, repo.RepoData)
+		}
+		for _, project := range Projects(repos) {
+			p := projectPage{ProjectSummary: project}
+			if data.Total > 0 {
+				p.Share = float64(project.CodeLines) / float64(data.Total) * 100
+			}
+			page.Projects = append(page.Projects, p)
+		}
+		if err := projects.Execute(w, page); err != nil {
+			http.Error(w, "❌ Error executing HTML template", http.StatusInternalServerError)
+		}
+	})
+}
+
+// Run of the history page, with its changes since the previous run
+type historyRun struct {
+	RunRecord
+	Delta   int
+	Added   []string
+	Removed []string
+}
+
+// Project, repository or language of the history page, between the first and the last run
+type historySerie struct {
+	Name  string
+	First int
+	Last  int
+	Delta int
+}
+
+type historyPageData struct {
+	Organizations []string
+	Organization  string
+	By            string
+	History       *History // nil without database
+	Runs          []historyRun
+	Series        []historySerie
+}
+
+// Register the growth of the organizations over their runs on /history.
+// The page only explains how to keep the history when the functions are nil.
+func RegisterHistoryPage(mux *http.ServeMux, organizations func() ([]string, error), history func(organization, by string) (*History, error)) {
+	page := template.Must(template.New("history").Funcs(template.FuncMap{"signed": signed}).Parse(historyTemplate))
+
+	mux.HandleFunc("/history", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		data := historyPageData{Organization: q.Get("organization"), By: q.Get("by")}
+		switch data.By {
+		case HistoryProject, HistoryRepository, HistoryLanguage:
+		default:
+			data.By = HistoryLanguage
+		}
+
+		if history != nil {
+			var err error
+			if data.Organizations, err = organizations(); err != nil {
+				http.Error(w, "❌ "+err.Error(), http.StatusInternalServerError)
+				return
+			}
+			if data.History, err = history(data.Organization, data.By); err != nil {
+				http.Error(w, "❌ "+err.Error(), http.StatusInternalServerError)
+				return
+			}
+			data.Organization = data.History.Organization
+			data.Runs, data.Series = historyRows(data.History)
+		}
+
+		if err := page.Execute(w, data); err != nil {
+			http.Error(w, "❌ Error executing HTML template", http.StatusInternalServerError)
+		}
+	})
+}
+
+// Rows of the history tables, the latest run first
+func historyRows(h *History) ([]historyRun, []historySerie) {
+	var runs []historyRun
+	for i := len(h.Runs) - 1; i >= 0; i-- {
+		run := historyRun{RunRecord: h.Runs[i]}
+		if i > 0 {
+			run.Delta = h.Runs[i].CodeLines - h.Runs[i-1].CodeLines
+			run.Added, run.Removed = h.Changes[i-1].Added, h.Changes[i-1].Removed
+		}
+		runs = append(runs, run)
+	}
+
+	var series []historySerie
+	for _, serie := range h.Series {
+		first, last := serie.CodeLines[0], serie.CodeLines[len(serie.CodeLines)-1]
+		series = append(series, historySerie{Name: serie.Name, First: first, Last: last, Delta: last - first})
+	}
+	return runs, series
+}
+
+// Project and repository of a page path
+func splitRepoPath(path string) (string, string) {
+	path = strings.Trim(strings.TrimPrefix(path, "/repos/"), "/")
+	i := strings.LastIndex(path, "/")
+	if i < 0 {
+		return "", path
+	}
+	project := path[:i]
+	if project == "-" {
+		project = ""
+	}
+	return project, path[i+1:]
+}
+
+func loadReposPage(directory string) (*reposPageData, error) {
+	Ginfo, err := LoadGlobalReport(directory)
+	if err != nil {
+		return nil, err
+	}
+	repos, err := LoadRepos(directory)
+	if err != nil {
+		return nil, fmt.Errorf("error reading repository results: %w", err)
+	}
+	SortReposByCodeLines(repos)
+
+	data := &reposPageData{Organization: Ginfo.Organization}
+	for _, repo := range repos {
+		data.Total += repo.TotalCodeLines
+	}
+	for _, repo := range repos {
+		page := repoPage{RepoData: repo, Path: RepoPath(repo.Project, repo.Repository)}
+		if data.Total > 0 {
+			page.Share = float64(repo.TotalCodeLines) / float64(data.Total) * 100
+		}
+		data.Repos = append(data.Repos, page)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,287 @@
+package report
+
+// Head of the repository pages, with the assets of the web visualization
+const pagesHead = `
+<head>
+  <meta charset="utf-8">
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  <title>Result Go LOC</title>
+  <link href="/dist/css/theme.min.css" rel="stylesheet" type="text/css" />
+  <script src="/dist/vendors/chartjs/chart.js"></script>
+  <style>
+    body { background-color: #f5f6fa; }
+    .header { background-color: #1b1b3a; padding: 1rem 0; }
+    th.sortable { cursor: pointer; }
+    td.num, th.num { text-align: right; }
+  </style>
+</head>
+`
+
+// HTML template of the repository list
+const reposTemplate = `<!DOCTYPE html>
+<html lang="en-US" dir="ltr">` + pagesHead + `
+<body>
+  <div class="header">
+    <div class="container"><a href="/"><img src="/dist/img/Logo.png" alt="GoLC" /></a></div>
+  </div>
+  <div class="container py-4">
+    {{if .Project}}<p><a href="/projects">Projects</a> / <a href="/repos">Repositories</a></p>{{end}}
+    <h1 class="fs-4">Repositories of {{if .Project}}the project {{.Project}} of {{end}}{{.Organization}}</h1>
+    <input id="search" class="form-control my-3" type="search" placeholder="Search a project, repository or branch" />
+    <table class="table table-sm" id="repos">
+      <thead>
+        <tr>
+          <th class="sortable" data-type="text">Project</th>
+          <th class="sortable" data-type="text">Repository</th>
+          <th class="sortable" data-type="text">Branch</th>
+          <th class="sortable num" data-type="number">Files</th>
+          <th class="sortable num" data-type="number">Code lines</th>
+          <th class="sortable num" data-type="number">Share %</th>
+        </tr>
+      </thead>
+      <tbody>
+      {{range .Repos}}
+        <tr>
+          <td>{{.Project}}</td>
+          <td><a href="{{.Path}}">{{.Repository}}</a></td>
+          <td>{{.Branch}}</td>
+          <td class="num" data-value="{{.TotalFiles}}">{{.TotalFiles}}</td>
+          <td class="num" data-value="{{.TotalCodeLines}}">{{.CodeLinesF}}</td>
+          <td class="num" data-value="{{.Share}}">{{printf "%.2f" .Share}}</td>
+        </tr>
+      {{end}}
+      </tbody>
+    </table>
+  </div>
+  <script>
+    var table = document.getElementById('repos');
+    var body = table.tBodies[0];
+
+    document.getElementById('search').addEventListener('input', function() {
+      var search = this.value.toLowerCase();
+      Array.from(body.rows).forEach(function(row) {
+        var text = row.cells[0].textContent + ' ' + row.cells[1].textContent + ' ' + row.cells[2].textContent;
+        row.style.display = text.toLowerCase().indexOf(search) >= 0 ? '' : 'none';
+      });
+    });
+
+    Array.from(table.tHead.rows[0].cells).forEach(function(th, column) {
+      th.addEventListener('click', function() {
+        var ascending = th.dataset.order !== 'asc';
+        th.dataset.order = ascending ? 'asc' : 'desc';
+        var rows = Array.from(body.rows);
+        rows.sort(function(a, b) {
+          var x = a.cells[column], y = b.cells[column];
+          var result = th.dataset.type === 'number'
+            ? parseFloat(x.dataset.value) - parseFloat(y.dataset.value)
+            : x.textContent.localeCompare(y.textContent);
+          return ascending ? result : -result;
+        });
+        rows.forEach(function(row) { body.appendChild(row); });
+      });
+    });
+  </script>
+</body>
+</html>
+`
+
+// HTML template of the page of a repository
+const repoTemplate = `<!DOCTYPE html>
+<html lang="en-US" dir="ltr">` + pagesHead + `
+<body>
+  <div class="header">
+    <div class="container"><a href="/"><img src="/dist/img/Logo.png" alt="GoLC" /></a></div>
+  </div>
+  <div class="container py-4">
+    <p><a href="/repos">Repositories</a> / {{if .Repo.Project}}<a href="/repos?project={{.Repo.Project}}">{{.Repo.Project}}</a>{{end}}</p>
+    <h1 class="fs-4">{{.Repo.Repository}}</h1>
+    <div class="row">
+      <div class="col-lg-6">
+        <div class="card text-white bg-primary mb-4">
+          <div class="card-body">
+            <p class="card-text">Organization : {{.Organization}}</p>
+            <p class="card-text">Project : {{.Repo.Project}}</p>
+            <p class="card-text">Branch : {{.Repo.Branch}}</p>
+            {{if .Repo.Commit}}<p class="card-text">Commit : {{.Repo.Commit}}</p>{{end}}
+            {{if not .Repo.Time.IsZero}}<p class="card-text">Analysed on : {{.Repo.Time.Format "2006-01-02 15:04"}}</p>{{end}}
+            <p class="card-text">Files : {{.Repo.TotalFiles}}</p>
+            <p class="card-text">Lines of code : {{.Repo.CodeLinesF}} ({{printf "%.2f" .Repo.Share}}% of the organization)</p>
+          </div>
+        </div>
+      </div>
+      <div class="col-lg-6">
+        <canvas id="languagesChart" width="350" height="350"></canvas>
+      </div>
+    </div>
+
+    <h2 class="fs-5 mt-4">Languages</h2>
+    <table class="table table-sm">
+      <thead><tr><th>Language</th><th class="num">Files</th><th class="num">Blank lines</th><th class="num">Comments</th><th class="num">Code lines</th><th class="num">%</th></tr></thead>
+      <tbody>
+      {{range $i, $l := .Repo.Results}}
+        <tr><td>{{$l.Language}}</td><td class="num">{{$l.Files}}</td><td class="num">{{$l.BlankLines}}</td><td class="num">{{$l.Comments}}</td><td class="num">{{$l.CodeLines}}</td><td class="num">{{printf "%.2f" (index $.Languages $i).Percentage}}</td></tr>
+      {{end}}
+      </tbody>
+    </table>
+  </div>
+  <script>
+    var languages = {{.Languages}};
+    new Chart(document.getElementById('languagesChart').getContext('2d'), {
+      type: 'doughnut',
+      data: {
+        labels: languages.map(function(l) { return l.Language; }),
+        datasets: [{
+          label: 'LOC',
+          data: languages.map(function(l) { return l.CodeLines; }),
+          borderWidth: 1
+        }]
+      },
+      options: { responsive: false }
+    });
+  </script>
+</body>
+</html>
+`
+
+// HTML template of the project list, the projects are the groups for Gitlab
+const projectsTemplate = `<!DOCTYPE html>
+<html lang="en-US" dir="ltr">` + pagesHead + `
+<body>
+  <div class="header">
+    <div class="container"><a href="/"><img src="/dist/img/Logo.png" alt="GoLC" /></a></div>
+  </div>
+  <div class="container py-4">
+    <h1 class="fs-4">Projects of {{.Organization}}</h1>
+    {{if not .Projects}}
+    <p>The repositories of this organization have no project.</p>
+    {{else}}
+    <canvas id="projectsChart" height="100"></canvas>
+    <table class="table table-sm mt-4" id="projects">
+      <thead>
+        <tr>
+          <th>Project</th>
+          <th class="num">Repositories</th>
+          <th class="num">Code lines</th>
+          <th class="num">Share %</th>
+          <th>Largest repository</th>
+          <th>Languages</th>
+        </tr>
+      </thead>
+      <tbody>
+      {{range .Projects}}
+        <tr>
+          <td><a href="/repos?project={{.Project}}">{{.Project}}</a></td>
+          <td class="num">{{.NumberRepos}}</td>
+          <td class="num">{{.TotalLinesOfCode}}</td>
+          <td class="num">{{printf "%.2f" .Share}}</td>
+          <td>{{.LargestRepository}} ({{.LinesOfCodeLargestRepo}})</td>
+          <td>{{range $i, $l := .Languages}}{{if lt $i 3}}{{if $i}}, {{end}}{{$l.Language}} {{printf "%.1f" $l.Percentage}}%{{end}}{{end}}</td>
+        </tr>
+      {{end}}
+      </tbody>
+    </table>
+    {{end}}
+  </div>
+  {{if .Projects}}
+  <script>
+    var projects = {{.Projects}};
+    new Chart(document.getElementById('projectsChart').getContext('2d'), {
+      type: 'bar',
+      data: {
+        labels: projects.slice(0, 20).map(function(p) { return p.Project; }),
+        datasets: [{ label: 'Code lines', data: projects.slice(0, 20).map(function(p) { return p.CodeLines; }) }]
+      }
+    });
+  </script>
+  {{end}}
+</body>
+</html>
+`
+
+// HTML template of the history of the runs
+const historyTemplate = `<!DOCTYPE html>
+<html lang="en-US" dir="ltr">` + pagesHead + `
+<body>
+  <div class="header">
+    <div class="container"><a href="/"><img src="/dist/img/Logo.png" alt="GoLC" /></a></div>
+  </div>
+  <div class="container py-4">
+  {{if not .History}}
+    <h1 class="fs-4">History</h1>
+    <p>The history of the runs is kept in a SQLite database : record the runs with <code>golc scan -sqlite golc.db</code> or <code>golc store -db golc.db</code>, then start the server with <code>-db golc.db</code>.</p>
+  {{else}}
+    <h1 class="fs-4">History of {{.Organization}}</h1>
+    <form class="row g-2 my-3" method="get" action="/history">
+      <div class="col-auto">
+        <select class="form-select" name="organization" onchange="this.form.submit()">
+        {{range .Organizations}}<option value="{{.}}"{{if eq . $.Organization}} selected{{end}}>{{.}}</option>{{end}}
+        </select>
+      </div>
+      <div class="col-auto">
+        <select class="form-select" name="by" onchange="this.form.submit()">
+          <option value="language"{{if eq .By "language"}} selected{{end}}>By language</option>
+          <option value="project"{{if eq .By "project"}} selected{{end}}>By project</option>
+          <option value="repo"{{if eq .By "repo"}} selected{{end}}>By repository</option>
+        </select>
+      </div>
+    </form>
+    {{if not .Runs}}
+    <p>No run recorded for this organization.</p>
+    {{else}}
+    <div class="row">
+      <div class="col-lg-6"><canvas id="totalChart" height="250"></canvas></div>
+      <div class="col-lg-6"><canvas id="seriesChart" height="250"></canvas></div>
+    </div>
+
+    <h2 class="fs-5 mt-4">Runs</h2>
+    <table class="table table-sm">
+      <thead><tr><th>Date</th><th>Platform</th><th class="num">Repositories</th><th class="num">Code lines</th><th class="num">Delta</th><th>Added</th><th>Removed</th></tr></thead>
+      <tbody>
+      {{range .Runs}}
+        <tr>
+          <td>{{.Time.Format "2006-01-02 15:04"}}</td>
+          <td>{{.Platform}}</td>
+          <td class="num">{{.Repositories}}</td>
+          <td class="num">{{.CodeLines}}</td>
+          <td class="num">{{signed .Delta}}</td>
+          <td>{{range .Added}}{{.}}<br />{{end}}</td>
+          <td>{{range .Removed}}{{.}}<br />{{end}}</td>
+        </tr>
+      {{end}}
+      </tbody>
+    </table>
+
+    <h2 class="fs-5 mt-4">Code lines since the first run</h2>
+    <table class="table table-sm">
+      <thead><tr><th>Name</th><th class="num">First run</th><th class="num">Last run</th><th class="num">Delta</th></tr></thead>
+      <tbody>
+      {{range .Series}}
+        <tr><td>{{.Name}}</td><td class="num">{{.First}}</td><td class="num">{{.Last}}</td><td class="num">{{signed .Delta}}</td></tr>
+      {{end}}
+      </tbody>
+    </table>
+    {{end}}
+  {{end}}
+  </div>
+  {{if .Runs}}
+  <script>
+    var runsHistory = {{.History}};
+    var labels = runsHistory.runs.map(function(r) { return r.time.substring(0, 10); });
+
+    new Chart(document.getElementById('totalChart').getContext('2d'), {
+      type: 'line',
+      data: { labels: labels, datasets: [{ label: 'Code lines of ' + runsHistory.organization, data: runsHistory.runs.map(function(r) { return r.code_lines; }) }] }
+    });
+
+    new Chart(document.getElementById('seriesChart').getContext('2d'), {
+      type: 'line',
+      data: {
+        labels: labels,
+        datasets: runsHistory.series.slice(0, 8).map(function(s) { return { label: s.name, data: s.code_lines }; })
+      }
+    });
+  </script>
+  {{end}}
+</body>
+</html>
+`
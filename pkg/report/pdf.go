@@ -0,0 +1,468 @@
+package report
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/colussim/GoLC/imgs"
+	"github.com/colussim/GoLC/pkg/devops/inventory"
+	"github.com/colussim/GoLC/pkg/utils"
+	"github.com/jung-kurt/gofpdf"
+)
+
+// Branding and content of the PDF report
+type PDFOptions struct {
+	Title   string // Title of the cover page, "GoLC Report" without it
+	Logo    string // PNG or JPEG file of the logo, the GoLC logo without it
+	Color   string // Main color as #rrggbb, #3399ff without it
+	Top     int    // Number of largest repositories listed, 10 without it
+	Version string // GoLC version, used when the run did not record its own
+}
+
+const (
+	pdfMargin     = 15.0
+	pdfPageWidth  = 210.0
+	pdfPageHeight = 297.0
+	pdfRowHeight  = 7.0
+	pdfPieSlices  = 8
+	pdfBars       = 10
+)
+
+// Colors of the charts after the main color
+var pdfPalette = [][3]int{
+	{255, 159, 64}, {75, 192, 192}, {255, 99, 132}, {153, 102, 255},
+	{255, 205, 86}, {46, 204, 113}, {201, 203, 207}, {231, 76, 60},
+}
+
+// Repository statuses of the decision log, in the order of the summary
+var pdfStatuses = []struct{ Status, Label string }{
+	{inventory.Selected, "Selected"},
+	{inventory.Excluded, "Excluded"},
+	{inventory.Archived, "Archived"},
+	{inventory.Empty, "Empty"},
+	{inventory.NoPermission, "No permission"},
+	{inventory.NoBranch, "No branch"},
+	{inventory.Error, "Error"},
+}
+
+type pdfReport struct {
+	pdf   *gofpdf.Fpdf
+	tr    func(string) string
+	color [3]int
+	logo  string // Logo of the cover page
+	logoB string // Logo of the other pages
+	title string
+}
+
+// Parse a #rrggbb color
+func parseColor(s string) ([3]int, error) {
+	var c [3]int
+	hex := strings.TrimPrefix(s, "#")
+	if len(hex) != 6 {
+		return c, fmt.Errorf("invalid color %q, expected #rrggbb", s)
+	}
+	for i := range c {
+		v, err := strconv.ParseUint(hex[2*i:2*i+2], 16, 8)
+		if err != nil {
+			return c, fmt.Errorf("invalid color %q, expected #rrggbb", s)
+		}
+		c[i] = int(v)
+	}
+	return c, nil
+}
+
+// Color of the nth serie of a chart
+func (r *pdfReport) serieColor(n int) [3]int {
+	if n == 0 {
+		return r.color
+	}
+	return pdfPalette[(n-1)%len(pdfPalette)]
+}
+
+// Draw the background and logo of the cover page, the title banner of the others
+func (r *pdfReport) header() {
+	pdf := r.pdf
+	if pdf.PageNo() == 1 {
+		pdf.SetFillColor(27, 27, 58)
+		pdf.Rect(0, 0, pdfPageWidth, pdfPageHeight, "F")
+		pdf.Image(r.logo, pdfMargin, pdfMargin, 60, 0, false, "", 0, "")
+		return
+	}
+
+	pdf.Image(r.logoB, pdfMargin, 8, 30, 0, false, "", 0, "")
+	pdf.SetFont("Arial", "", 9)
+	pdf.SetTextColor(120, 120, 120)
+	pdf.SetXY(pdfMargin, 10)
+	pdf.CellFormat(pdfPageWidth-2*pdfMargin, 6, r.tr(r.title), "", 0, "R", false, 0, "")
+	pdf.SetDrawColor(r.color[0], r.color[1], r.color[2])
+	pdf.SetLineWidth(0.5)
+	pdf.Line(pdfMargin, 20, pdfPageWidth-pdfMargin, 20)
+	pdf.SetY(25)
+}
+
+func (r *pdfReport) footer() {
+	pdf := r.pdf
+	if pdf.PageNo() == 1 {
+		return
+	}
+	pdf.SetY(-12)
+	pdf.SetFont("Arial", "", 8)
+	pdf.SetTextColor(120, 120, 120)
+	pdf.CellFormat(0, 6, fmt.Sprintf("Page %d/{nb}", pdf.PageNo()), "", 0, "C", false, 0, "")
+}
+
+// Start a section with its title, on a new page if there is not room for its first lines
+func (r *pdfReport) section(title string, room float64) {
+	pdf := r.pdf
+	if pdf.GetY()+room > pdfPageHeight-pdfMargin {
+		pdf.AddPage()
+	}
+	pdf.Ln(4)
+	pdf.SetFont("Arial", "B", 14)
+	pdf.SetTextColor(r.color[0], r.color[1], r.color[2])
+	pdf.CellFormat(0, 10, r.tr(title), "", 1, "", false, 0, "")
+	pdf.SetTextColor(40, 40, 40)
+}
+
+// Truncate a text to the width of a cell
+func (r *pdfReport) fit(s string, width float64) string {
+	s = r.tr(s)
+	if r.pdf.GetStringWidth(s) <= width-2 {
+		return s
+	}
+	for len(s) > 0 && r.pdf.GetStringWidth(s+"...") > width-2 {
+		s = s[:len(s)-1]
+	}
+	return s + "..."
+}
+
+// Draw a table, its header is repeated on every page it spans.
+// align holds the alignment of each column : l, c or r.
+func (r *pdfReport) table(header []string, widths []float64, align string, rows [][]string) {
+	pdf := r.pdf
+
+	drawHeader := func() {
+		pdf.SetFont("Arial", "B", 9)
+		pdf.SetFillColor(r.color[0], r.color[1], r.color[2])
+		pdf.SetTextColor(255, 255, 255)
+		for i, h := range header {
+			pdf.CellFormat(widths[i], pdfRowHeight, r.tr(h), "", 0, strings.ToUpper(align[i:i+1]), true, 0, "")
+		}
+		pdf.Ln(-1)
+		pdf.SetFont("Arial", "", 9)
+		pdf.SetTextColor(40, 40, 40)
+	}
+
+	if pdf.GetY()+2*pdfRowHeight > pdfPageHeight-pdfMargin {
+		pdf.AddPage()
+	}
+	drawHeader()
+	for n, row := range rows {
+		if pdf.GetY()+pdfRowHeight > pdfPageHeight-pdfMargin {
+			pdf.AddPage()
+			drawHeader()
+		}
+		if n%2 == 0 {
+			pdf.SetFillColor(240, 244, 250)
+		} else {
+			pdf.SetFillColor(255, 255, 255)
+		}
+		for i, cell := range row {
+			pdf.CellFormat(widths[i], pdfRowHeight, r.fit(cell, widths[i]), "", 0, strings.ToUpper(align[i:i+1]), true, 0, "")
+		}
+		pdf.Ln(-1)
+	}
+}
+
+// Draw the key figures of the executive summary as boxes
+func (r *pdfReport) figures(figures [][2]string) {
+	pdf := r.pdf
+	const perLine = 3
+	width := (pdfPageWidth - 2*pdfMargin - float64(perLine-1)*4) / perLine
+
+	y := pdf.GetY()
+	for i, f := range figures {
+		x := pdfMargin + float64(i%perLine)*(width+4)
+		if i > 0 && i%perLine == 0 {
+			y += 24
+		}
+		pdf.SetFillColor(240, 244, 250)
+		pdf.Rect(x, y, width, 20, "F")
+		pdf.SetFillColor(r.color[0], r.color[1], r.color[2])
+		pdf.Rect(x, y, 1.5, 20, "F")
+
+		pdf.SetXY(x+4, y+3)
+		pdf.SetFont("Arial", "B", 13)
+		pdf.SetTextColor(40, 40, 40)
+		pdf.CellFormat(width-6, 7, r.fit(f[1], width-6), "", 0, "", false, 0, "")
+		pdf.SetXY(x+4, y+11)
+		pdf.SetFont("Arial", "", 8)
+		pdf.SetTextColor(120, 120, 120)
+		pdf.CellFormat(width-6, 5, r.tr(f[0]), "", 0, "", false, 0, "")
+	}
+	pdf.SetXY(pdfMargin, y+24)
+}
+
+// Draw a pie chart of the code lines by language with its legend
+func (r *pdfReport) pieChart(languages []LanguageData) {
+	pdf := r.pdf
+
+	type slice struct {
+		label string
+		value int
+	}
+	var slices []slice
+	total := 0
+	for i, lang := range languages {
+		total += lang.CodeLines
+		if i < pdfPieSlices-1 || len(languages) == pdfPieSlices {
+			slices = append(slices, slice{lang.Language, lang.CodeLines})
+		} else if i == pdfPieSlices-1 {
+			slices = append(slices, slice{"Others", lang.CodeLines})
+		} else {
+			slices[len(slices)-1].value += lang.CodeLines
+		}
+	}
+	if total == 0 {
+		return
+	}
+
+	const radius = 35.0
+	top := pdf.GetY()
+	cx, cy := pdfMargin+radius+5, top+radius+2
+
+	start := -90.0
+	for i, s := range slices {
+		sweep := 360 * float64(s.value) / float64(total)
+		points := []gofpdf.PointType{{X: cx, Y: cy}}
+		steps := int(math.Ceil(sweep/2)) + 1
+		for k := 0; k <= steps; k++ {
+			a := (start + sweep*float64(k)/float64(steps)) * math.Pi / 180
+			points = append(points, gofpdf.PointType{X: cx + radius*math.Cos(a), Y: cy + radius*math.Sin(a)})
+		}
+		c := r.serieColor(i)
+		pdf.SetFillColor(c[0], c[1], c[2])
+		pdf.Polygon(points, "F")
+		start += sweep
+	}
+
+	// Legend
+	x := cx + radius + 15
+	pdf.SetFont("Arial", "", 9)
+	pdf.SetTextColor(40, 40, 40)
+	for i, s := range slices {
+		y := top + 6 + float64(i)*8
+		c := r.serieColor(i)
+		pdf.SetFillColor(c[0], c[1], c[2])
+		pdf.Rect(x, y+1, 4, 4, "F")
+		pdf.SetXY(x+6, y)
+		label := fmt.Sprintf("%s  %.2f %%", s.label, 100*float64(s.value)/float64(total))
+		pdf.CellFormat(pdfPageWidth-pdfMargin-x-6, 6, r.tr(label), "", 0, "", false, 0, "")
+	}
+
+	pdf.SetXY(pdfMargin, top+2*radius+8)
+}
+
+// Draw a horizontal bar chart of the languages with the most code lines
+func (r *pdfReport) barChart(languages []LanguageData) {
+	pdf := r.pdf
+	if len(languages) > pdfBars {
+		languages = languages[:pdfBars]
+	}
+	if len(languages) == 0 || languages[0].CodeLines == 0 {
+		return
+	}
+
+	const labelWidth, valueWidth, barHeight = 35.0, 20.0, 6.0
+	maxWidth := pdfPageWidth - 2*pdfMargin - labelWidth - valueWidth
+	max := float64(languages[0].CodeLines)
+
+	pdf.SetFont("Arial", "", 9)
+	pdf.SetTextColor(40, 40, 40)
+	for i, lang := range languages {
+		y := pdf.GetY()
+		pdf.SetX(pdfMargin)
+		pdf.CellFormat(labelWidth, barHeight, r.fit(lang.Language, labelWidth), "", 0, "", false, 0, "")
+		width := maxWidth * float64(lang.CodeLines) / max
+		c := r.serieColor(i)
+		pdf.SetFillColor(c[0], c[1], c[2])
+		pdf.Rect(pdfMargin+labelWidth, y+0.5, width, barHeight-1, "F")
+		pdf.SetX(pdfMargin + labelWidth + width + 2)
+		pdf.CellFormat(valueWidth, barHeight, lang.CodeLinesF, "", 1, "", false, 0, "")
+		pdf.Ln(1.5)
+	}
+}
+
+// Generate <directory>/GlobalReport.pdf from the report data : a cover page, the executive
+// summary with the repository statuses of the run, the language charts, the largest
+// repositories and every repository by project
+func GeneratePDF(directory string, data *PageData, opts PDFOptions) error {
+	Ginfo := data.GlobalReport
+
+	repos, err := LoadRepos(directory)
+	if err != nil {
+		return fmt.Errorf("error reading repository results: %w", err)
+	}
+	run, err := LoadRunInfo(directory)
+	if err != nil {
+		return fmt.Errorf("error reading run summary: %w", err)
+	}
+
+	if opts.Title == "" {
+		opts.Title = "GoLC Report"
+	}
+	if opts.Color == "" {
+		opts.Color = "#3399ff"
+	}
+	if opts.Top <= 0 {
+		opts.Top = 10
+	}
+	color, err := parseColor(opts.Color)
+	if err != nil {
+		return err
+	}
+
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(pdfMargin, pdfMargin, pdfMargin)
+	pdf.SetAutoPageBreak(false, pdfMargin)
+	pdf.AliasNbPages("")
+
+	r := &pdfReport{
+		pdf:   pdf,
+		tr:    pdf.UnicodeTranslatorFromDescriptor(""),
+		color: color,
+		logo:  opts.Logo,
+		logoB: opts.Logo,
+		title: opts.Title,
+	}
+
+	// The GoLC logos are embedded in the binary
+	if opts.Logo == "" {
+		r.logo, r.logoB = "Logo.png", "Logob.png"
+		for _, name := range []string{r.logo, r.logoB} {
+			file, err := imgs.FS.Open(name)
+			if err != nil {
+				return err
+			}
+			pdf.RegisterImageOptionsReader(name, gofpdf.ImageOptions{ImageType: "PNG"}, file)
+			file.Close()
+		}
+	}
+
+	pdf.SetHeaderFunc(r.header)
+	pdf.SetFooterFunc(r.footer)
+
+	version := run.Version
+	if version == "" {
+		version = opts.Version
+	}
+	date := "unknown"
+	if !run.Start.IsZero() {
+		date = run.Start.Format("2006-01-02 15:04")
+	}
+
+	// Cover page
+	pdf.AddPage()
+	pdf.SetY(100)
+	pdf.SetFont("Arial", "B", 28)
+	pdf.SetTextColor(255, 255, 255)
+	pdf.MultiCell(0, 12, r.tr(opts.Title), "", "", false)
+	pdf.Ln(4)
+	pdf.SetFont("Arial", "", 16)
+	pdf.SetTextColor(color[0], color[1], color[2])
+	pdf.MultiCell(0, 9, r.tr("Organization : "+Ginfo.Organization), "", "", false)
+	pdf.SetFont("Arial", "", 11)
+	pdf.SetTextColor(200, 200, 200)
+	pdf.Ln(6)
+	for _, line := range []string{
+		"DevOps platform : " + Ginfo.DevOpsPlatform,
+		"Date of the analysis : " + date,
+		"GoLC version : " + version,
+	} {
+		pdf.CellFormat(0, 7, r.tr(line), "", 1, "", false, 0, "")
+	}
+
+	// Executive summary
+	pdf.AddPage()
+	r.section("Executive summary", 60)
+	r.figures([][2]string{
+		{"Total lines of code", Ginfo.TotalLinesOfCode},
+		{"Repositories analyzed", strconv.Itoa(Ginfo.NumberRepos)},
+		{"Languages", strconv.Itoa(len(data.Languages))},
+		{"Largest repository", Ginfo.LargestRepository},
+		{"Lines of code of the largest repository", Ginfo.LinesOfCodeLargestRepo},
+		{"Repositories failed", strconv.Itoa(run.Failed)},
+	})
+
+	r.section("Repositories of the organization", 40)
+	if run.Statuses == nil {
+		pdf.SetFont("Arial", "I", 9)
+		pdf.CellFormat(0, 7, "The repository statuses were not recorded for this run.", "", 1, "", false, 0, "")
+	} else {
+		var rows [][]string
+		for _, s := range pdfStatuses {
+			rows = append(rows, []string{s.Label, strconv.Itoa(run.Statuses[s.Status])})
+		}
+		r.table([]string{"Status", "Repositories"}, []float64{60, 30}, "lr", rows)
+	}
+
+	r.section("Run metadata", 40)
+	duration := "unknown"
+	if run.Duration > 0 {
+		duration = (time.Duration(run.Duration) * time.Second).String()
+	}
+	r.table([]string{"Property", "Value"}, []float64{60, 120}, "ll", [][]string{
+		{"Date", date},
+		{"Duration", duration},
+		{"DevOps platform", Ginfo.DevOpsPlatform},
+		{"Organization", Ginfo.Organization},
+		{"GoLC version", version},
+	})
+
+	// Languages
+	pdf.AddPage()
+	r.section("Languages", 90)
+	r.pieChart(data.Languages)
+	r.section(fmt.Sprintf("Top %d languages", pdfBars), 30)
+	r.barChart(data.Languages)
+
+	var rows [][]string
+	for _, lang := range data.Languages {
+		rows = append(rows, []string{lang.Language, lang.CodeLinesF, fmt.Sprintf("%.2f %%", lang.Percentage)})
+	}
+	r.section("Code lines by language", 30)
+	r.table([]string{"Language", "Code lines", "Share"}, []float64{90, 45, 45}, "lrr", rows)
+
+	// Largest repositories
+	SortReposByCodeLines(repos)
+	top := repos
+	if len(top) > opts.Top {
+		top = top[:opts.Top]
+	}
+	rows = nil
+	for i, repo := range top {
+		rows = append(rows, []string{strconv.Itoa(i + 1), repo.Project, repo.Repository, repo.Branch, repo.CodeLinesF()})
+	}
+	pdf.AddPage()
+	r.section(fmt.Sprintf("Top %d largest repositories", len(top)), 30)
+	r.table([]string{"#", "Project", "Repository", "Branch", "Code lines"}, []float64{10, 45, 60, 40, 25}, "rlllr", rows)
+
+	// Every repository by project
+	byProject := append([]RepoData(nil), repos...)
+	sort.SliceStable(byProject, func(i, j int) bool {
+		return byProject[i].Project < byProject[j].Project
+	})
+	rows = nil
+	for _, repo := range byProject {
+		rows = append(rows, []string{repo.Project, repo.Repository, repo.Branch, strconv.Itoa(repo.TotalFiles), utils.FormatCodeLines(float64(repo.TotalCodeLines))})
+	}
+	r.section("Repositories by project", 30)
+	r.table([]string{"Project", "Repository", "Branch", "Files", "Code lines"}, []float64{45, 60, 40, 15, 20}, "lllrr", rows)
+
+	return pdf.OutputFileAndClose(filepath.Join(directory, "GlobalReport.pdf"))
+}
@@ -0,0 +1,82 @@
+package report
+
+import (
+	"sort"
+
+	"github.com/colussim/GoLC/pkg/utils"
+)
+
+// Subtotals of a project, or of a group for Gitlab where the project is the path of the group
+type ProjectSummary struct {
+	Project                string         `json:"Project"`
+	NumberRepos            int            `json:"NumberRepos"`
+	CodeLines              int            `json:"CodeLines"`
+	TotalLinesOfCode       string         `json:"TotalLinesOfCode"`
+	LargestRepository      string         `json:"LargestRepository"`
+	CodeLinesLargestRepo   int            `json:"CodeLinesLargestRepo"`
+	LinesOfCodeLargestRepo string         `json:"LinesOfCodeLargestRepo"`
+	Languages              []LanguageData `json:"Languages"`
+}
+
+// Subtotals of the projects of the repositories, the largest first.
+// There are none when no repository has a project, as for the File platform.
+func Projects(repos []RepoData) []ProjectSummary {
+	projects := make(map[string]*ProjectSummary)
+	languages := make(map[string]map[string]int)
+	var names []string
+
+	for _, repo := range repos {
+		if repo.Project == "" {
+			continue
+		}
+		project := projects[repo.Project]
+		if project == nil {
+			project = &ProjectSummary{Project: repo.Project}
+			projects[repo.Project] = project
+			languages[repo.Project] = make(map[string]int)
+			names = append(names, repo.Project)
+		}
+
+		project.NumberRepos++
+		project.CodeLines += repo.TotalCodeLines
+		if project.LargestRepository == "" || repo.TotalCodeLines > project.CodeLinesLargestRepo {
+			project.LargestRepository = repo.Repository
+			project.CodeLinesLargestRepo = repo.TotalCodeLines
+		}
+		for _, l := range repo.Results {
+			languages[repo.Project][l.Language] += l.CodeLines
+		}
+	}
+
+	summaries := make([]ProjectSummary, 0, len(names))
+	for _, name := range names {
+		project := projects[name]
+		project.TotalLinesOfCode = utils.FormatCodeLines(float64(project.CodeLines))
+		project.LinesOfCodeLargestRepo = utils.FormatCodeLines(float64(project.CodeLinesLargestRepo))
+
+		project.Languages = []LanguageData{}
+		for language, codeLines := range languages[name] {
+			lang := LanguageData{Language: language, CodeLines: codeLines}
+			if project.CodeLines > 0 {
+				lang.Percentage = float64(codeLines) / float64(project.CodeLines) * 100
+			}
+			lang.FormatCodeLines()
+			project.Languages = append(project.Languages, lang)
+		}
+		sort.Slice(project.Languages, func(i, j int) bool {
+			if project.Languages[i].CodeLines != project.Languages[j].CodeLines {
+				return project.Languages[i].CodeLines > project.Languages[j].CodeLines
+			}
+			return project.Languages[i].Language < project.Languages[j].Language
+		})
+		summaries = append(summaries, *project)
+	}
+
+	sort.SliceStable(summaries, func(i, j int) bool {
+		if summaries[i].CodeLines != summaries[j].CodeLines {
+			return summaries[i].CodeLines > summaries[j].CodeLines
+		}
+		return summaries[i].Project < summaries[j].Project
+	})
+	return summaries
+}
@@ -0,0 +1,141 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/colussim/GoLC/pkg/utils"
+)
+
+type Globalinfo struct {
+	Organization           string `json:"Organization"`
+	TotalLinesOfCode       string `json:"TotalLinesOfCode"`
+	LargestRepository      string `json:"LargestRepository"`
+	LinesOfCodeLargestRepo string `json:"LinesOfCodeLargestRepo"`
+	DevOpsPlatform         string `json:"DevOpsPlatform"`
+	NumberRepos            int    `json:"NumberRepos"`
+	// Subtotals of the projects, or of the groups for Gitlab
+	Projects []ProjectSummary `json:"Projects,omitempty"`
+}
+
+type LanguageData struct {
+	Language   string  `json:"Language"`
+	CodeLines  int     `json:"CodeLines"`
+	Percentage float64 `json:"Percentage"`
+	CodeLinesF string  `json:"CodeLinesF"`
+}
+
+type PageData struct {
+	Languages    []LanguageData
+	GlobalReport Globalinfo
+}
+
+type LanguageData1 struct {
+	Language  string `json:"Language"`
+	CodeLines int    `json:"CodeLines"`
+}
+
+func (l *LanguageData) FormatCodeLines() {
+	l.CodeLinesF = utils.FormatCodeLines(float64(l.CodeLines))
+}
+
+// Sum the code lines by language of the repositories of a results directory.
+// They are read like the global report, from the journal, so that the results kept
+// from a previous run and not analysed again are not counted.
+func CodeLinesByLanguage(directory string) ([]LanguageData1, error) {
+	repos, err := LoadRepos(directory)
+	if err != nil {
+		return nil, err
+	}
+
+	ligneDeCodeParLangage := make(map[string]int)
+	for _, repo := range repos {
+		for _, result := range repo.Results {
+			ligneDeCodeParLangage[result.Language] += result.CodeLines
+		}
+	}
+
+	// Create output structure
+	var resultats []LanguageData1
+	for lang, total := range ligneDeCodeParLangage {
+		resultats = append(resultats, LanguageData1{
+			Language:  lang,
+			CodeLines: total,
+		})
+	}
+
+	return resultats, nil
+}
+
+// Read the GlobalReport.json file of a results directory
+func LoadGlobalReport(directory string) (Globalinfo, error) {
+	var Ginfo Globalinfo
+
+	data, err := os.ReadFile(filepath.Join(directory, "GlobalReport.json"))
+	if err != nil {
+		return Ginfo, fmt.Errorf("error reading GlobalReport.json file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &Ginfo); err != nil {
+		return Ginfo, fmt.Errorf("error decoding JSON GlobalReport.json file: %w", err)
+	}
+
+	return Ginfo, nil
+}
+
+// Build the report data of a results directory.
+// The language totals are also recorded in <directory>/code_lines_by_language.json.
+func Load(directory string) (*PageData, error) {
+	resultats, err := CodeLinesByLanguage(directory)
+	if err != nil {
+		return nil, fmt.Errorf("error reading files: %w", err)
+	}
+
+	// Writing results to a JSON file
+	outputData, err := json.MarshalIndent(resultats, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("error creating output JSON file: %w", err)
+	}
+	outputFile := filepath.Join(directory, "code_lines_by_language.json")
+	if err := os.WriteFile(outputFile, outputData, 0644); err != nil {
+		return nil, fmt.Errorf("error writing to output JSON file: %w", err)
+	}
+
+	Ginfo, err := LoadGlobalReport(directory)
+	if err != nil {
+		return nil, err
+	}
+
+	// Calculating percentages
+	languages := make([]LanguageData, 0, len(resultats))
+	total := 0
+	for _, lang := range resultats {
+		total += lang.CodeLines
+	}
+	for _, lang := range resultats {
+		l := LanguageData{
+			Language:  lang.Language,
+			CodeLines: lang.CodeLines,
+		}
+		if total > 0 {
+			l.Percentage = float64(lang.CodeLines) / float64(total) * 100
+		}
+		l.FormatCodeLines()
+		languages = append(languages, l)
+	}
+	// Largest first, the charts and the top lists rely on this order
+	sort.Slice(languages, func(i, j int) bool {
+		if languages[i].CodeLines != languages[j].CodeLines {
+			return languages[i].CodeLines > languages[j].CodeLines
+		}
+		return languages[i].Language < languages[j].Language
+	})
+
+	return &PageData{
+		Languages:    languages,
+		GlobalReport: Ginfo,
+	}, nil
+}
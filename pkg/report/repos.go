@@ -0,0 +1,126 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/colussim/GoLC/pkg/journal"
+	"github.com/colussim/GoLC/pkg/utils"
+)
+
+// Journal of the analysed repositories, relative to the results directory
+const journalPath = "config/journal.jsonl"
+
+type LanguageResult struct {
+	Language   string `json:"Language"`
+	Files      int    `json:"Files"`
+	Lines      int    `json:"Lines"`
+	BlankLines int    `json:"BlankLines"`
+	Comments   int    `json:"Comments"`
+	CodeLines  int    `json:"CodeLines"`
+}
+
+// Results of a repository, read from its json report
+type RepoData struct {
+	Project         string
+	Repository      string
+	Branch          string
+	Commit          string
+	Time            time.Time // End of the analysis, zero without journal
+	ResultFile      string
+	TotalFiles      int              `json:"TotalFiles"`
+	TotalLines      int              `json:"TotalLines"`
+	TotalBlankLines int              `json:"TotalBlankLines"`
+	TotalComments   int              `json:"TotalComments"`
+	TotalCodeLines  int              `json:"TotalCodeLines"`
+	Results         []LanguageResult `json:"Results"`
+}
+
+func (r RepoData) CodeLinesF() string {
+	return utils.FormatCodeLines(float64(r.TotalCodeLines))
+}
+
+// Repositories of a results directory with their json report.
+// The journal gives the project and branch of each report, without it they are read
+// from the metadata of the reports, and only the report names are known for older reports.
+func resultEntries(directory string) ([]journal.Entry, error) {
+	entries, err := journal.Read(filepath.Join(directory, journalPath))
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) > 0 {
+		return entries, nil
+	}
+
+	files, err := os.ReadDir(directory)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		name := file.Name()
+		if file.IsDir() || !strings.HasPrefix(name, "Result_") || filepath.Ext(name) != ".json" {
+			continue
+		}
+		metadata, err := ReadResultMetadata(filepath.Join(directory, name))
+		if err != nil {
+			return nil, err
+		}
+		if metadata != nil {
+			entries = append(entries, metadata.Entry(name))
+			continue
+		}
+		entries = append(entries, journal.Entry{
+			RepoSlug:   strings.TrimSuffix(strings.TrimPrefix(name, "Result_"), ".json"),
+			ResultFile: name,
+		})
+	}
+	return entries, nil
+}
+
+// Read the results of every repository of a results directory, in the order of the analysis
+func LoadRepos(directory string) ([]RepoData, error) {
+	entries, err := resultEntries(directory)
+	if err != nil {
+		return nil, err
+	}
+
+	repos := make([]RepoData, 0, len(entries))
+	for _, entry := range entries {
+		data, err := os.ReadFile(filepath.Join(directory, entry.ResultFile))
+		if err != nil {
+			return nil, err
+		}
+
+		var repo RepoData
+		if err := json.Unmarshal(data, &repo); err != nil {
+			return nil, fmt.Errorf("error parsing %s: %w", entry.ResultFile, err)
+		}
+
+		repo.Project = entry.ProjectKey
+		repo.Repository = entry.RepoSlug
+		repo.Branch = entry.Branch
+		repo.Commit = entry.Commit
+		repo.Time = entry.Time
+		repo.ResultFile = entry.ResultFile
+		if entry.Namespace != "" {
+			// Gitlab : the namespace is the full path of the repository
+			repo.Project = path.Dir(entry.Namespace)
+		}
+		repos = append(repos, repo)
+	}
+
+	return repos, nil
+}
+
+// Sort repositories by code lines, largest first
+func SortReposByCodeLines(repos []RepoData) {
+	sort.SliceStable(repos, func(i, j int) bool {
+		return repos[i].TotalCodeLines > repos[j].TotalCodeLines
+	})
+}
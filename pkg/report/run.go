@@ -0,0 +1,46 @@
+package report
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Summary of the last run, relative to the results directory
+const runInfoPath = "config/run.json"
+
+// Operational summary of a run
+type RunInfo struct {
+	Start    time.Time      `json:"Start"`
+	Duration float64        `json:"DurationSeconds"`
+	Analysed int            `json:"Analysed"`
+	Failed   int            `json:"Failed"`
+	Statuses map[string]int `json:"Statuses"`          // Repositories by inventory status : selected, excluded, archived ...
+	Version  string         `json:"Version,omitempty"` // GoLC version of the run
+}
+
+// Write the summary of the run of a results directory
+func SaveRunInfo(directory string, info RunInfo) error {
+	data, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(directory, runInfoPath), data, 0644)
+}
+
+// Read the summary of the run of a results directory, it is empty for the results of older versions
+func LoadRunInfo(directory string) (RunInfo, error) {
+	var info RunInfo
+
+	data, err := os.ReadFile(filepath.Join(directory, runInfoPath))
+	if os.IsNotExist(err) {
+		return info, nil
+	}
+	if err != nil {
+		return info, err
+	}
+
+	err = json.Unmarshal(data, &info)
+	return info, err
+}
@@ -0,0 +1,87 @@
+package report
+
+import (
+	"crypto/tls"
+	"fmt"
+	"html/template"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/colussim/GoLC/dist"
+)
+
+// Build the HTTP handler of the web visualization.
+// Static assets are served under /dist/ from distDir, or from the assets embedded
+// in the binary when it is empty. Other handlers can be added to the returned mux.
+func Handler(data *PageData, distDir string) *http.ServeMux {
+	// Load HTML template
+	tmpl := template.Must(template.New("index").Parse(htmlTemplate))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		// Run Template
+		if err := tmpl.Execute(w, data); err != nil {
+			http.Error(w, "❌ Error executing HTML template", http.StatusInternalServerError)
+			return
+		}
+	})
+
+	assets := http.FS(dist.FS)
+	if distDir != "" {
+		assets = http.Dir(distDir)
+	}
+	mux.Handle("/dist/", http.StripPrefix("/dist/", http.FileServer(assets)))
+
+	return mux
+}
+
+// URL of a listen address, ":8080" is http://localhost:8080, or https with TLS
+func ServerURL(addr string, secure bool) string {
+	if strings.HasPrefix(addr, ":") {
+		addr = "localhost" + addr
+	}
+	if secure {
+		return "https://" + addr
+	}
+	return "http://" + addr
+}
+
+// Timeouts of the web visualization, a client cannot hold a connection open by sending its request slowly
+const (
+	readHeaderTimeout = 10 * time.Second
+	readTimeout       = 30 * time.Second
+	writeTimeout      = 60 * time.Second
+	idleTimeout       = 120 * time.Second
+)
+
+// HTTP server of the web visualization with its timeouts
+func newServer(handler http.Handler, tlsConfig *tls.Config) *http.Server {
+	return &http.Server{
+		Handler:           handler,
+		TLSConfig:         tlsConfig,
+		ReadHeaderTimeout: readHeaderTimeout,
+		ReadTimeout:       readTimeout,
+		WriteTimeout:      writeTimeout,
+		IdleTimeout:       idleTimeout,
+	}
+}
+
+// Start the web visualization on a listen address like ":8080" or "127.0.0.1:9090",
+// over HTTPS when tlsConfig is not nil
+func StartServer(addr string, handler http.Handler, tlsConfig *tls.Config) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	srv := newServer(handler, tlsConfig)
+
+	fmt.Printf("✅ Server started on %s\n", ServerURL(addr, tlsConfig != nil))
+	fmt.Println("✅ please type < Ctrl+C> to stop the server")
+	if tlsConfig != nil {
+		// The certificate is in tlsConfig
+		return srv.ServeTLS(listener, "", "")
+	}
+	return srv.Serve(listener)
+}
@@ -0,0 +1,107 @@
+package report
+
+// HTML template of the static report, every asset is inlined
+const staticTemplate = `<!DOCTYPE html>
+<html lang="en-US" dir="ltr">
+<head>
+  <meta charset="utf-8">
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  <title>Result Go LOC - {{.GlobalReport.Organization}}</title>
+  <style>{{.CSS}}</style>
+  <style>
+    body { background-color: #f5f6fa; }
+    .header { background-color: #1b1b3a; padding: 1.5rem 0; }
+    .chart-container { max-width: 400px; }
+    details summary { cursor: pointer; }
+    td.num, th.num { text-align: right; }
+    .drill-down { background-color: #fff; }
+  </style>
+</head>
+<body>
+  <div class="header">
+    <div class="container">
+      <img src="{{.Logo}}" alt="GoLC" />
+      <span class="text-white ms-3">Generated on {{.Generated}}</span>
+    </div>
+  </div>
+
+  <div class="container py-4">
+    <div class="row">
+      <div class="col-lg-6">
+        <h1 class="fs-5">Results</h1>
+        <div class="card text-white bg-primary mb-4">
+          <h5 class="card-header text-white">Organization: {{.GlobalReport.Organization}} ({{.GlobalReport.DevOpsPlatform}})</h5>
+          <div class="card-body">
+            <p class="card-text">Total lines Of code : {{.GlobalReport.TotalLinesOfCode}}</p>
+            <p class="card-text">Largest Repository : {{.GlobalReport.LargestRepository}}</p>
+            <p class="card-text">Lines of code largest Repository : {{.GlobalReport.LinesOfCodeLargestRepo}}</p>
+            <p class="card-text">Number of Repositories analyzed : {{.GlobalReport.NumberRepos}}</p>
+          </div>
+        </div>
+      </div>
+      <div class="col-lg-6">
+        <div class="chart-container">
+          <canvas id="languagesChart" width="400" height="400"></canvas>
+        </div>
+      </div>
+    </div>
+
+    <h2 class="fs-4 mt-4">Languages</h2>
+    <table class="table table-sm">
+      <thead><tr><th>Language</th><th class="num">Code lines</th><th class="num">%</th></tr></thead>
+      <tbody>
+      {{range .Languages}}
+        <tr><td>{{.Language}}</td><td class="num">{{.CodeLinesF}}</td><td class="num">{{printf "%.2f" .Percentage}}</td></tr>
+      {{end}}
+      </tbody>
+    </table>
+
+    <h2 class="fs-4 mt-4">Repositories</h2>
+    <table class="table table-sm">
+      <thead><tr><th>Project</th><th>Repository</th><th>Branch</th><th class="num">Files</th><th class="num">Lines</th><th class="num">Code lines</th></tr></thead>
+      <tbody>
+      {{range .Repos}}
+        <tr>
+          <td>{{.Project}}</td>
+          <td>
+            <details>
+              <summary>{{.Repository}}</summary>
+              <table class="table table-sm drill-down mt-2">
+                <thead><tr><th>Language</th><th class="num">Files</th><th class="num">Blank lines</th><th class="num">Comments</th><th class="num">Code lines</th></tr></thead>
+                <tbody>
+                {{range .Results}}
+                  <tr><td>{{.Language}}</td><td class="num">{{.Files}}</td><td class="num">{{.BlankLines}}</td><td class="num">{{.Comments}}</td><td class="num">{{.CodeLines}}</td></tr>
+                {{end}}
+                </tbody>
+              </table>
+            </details>
+          </td>
+          <td>{{.Branch}}</td>
+          <td class="num">{{.TotalFiles}}</td>
+          <td class="num">{{.TotalLines}}</td>
+          <td class="num">{{.CodeLinesF}}</td>
+        </tr>
+      {{end}}
+      </tbody>
+    </table>
+  </div>
+
+  <script>{{.ChartJS}}</script>
+  <script>
+    var languages = {{.Languages}};
+    new Chart(document.getElementById('languagesChart').getContext('2d'), {
+      type: 'doughnut',
+      data: {
+        labels: languages.map(function(l) { return l.Language; }),
+        datasets: [{
+          label: 'LOC',
+          data: languages.map(function(l) { return l.CodeLines; }),
+          borderWidth: 1
+        }]
+      },
+      options: { responsive: false }
+    });
+  </script>
+</body>
+</html>
+`
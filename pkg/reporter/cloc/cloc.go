@@ -0,0 +1,121 @@
+package cloc
+
+import (
+	"time"
+
+	"github.com/colussim/GoLC/pkg/reporter"
+	"github.com/colussim/GoLC/pkg/sorter"
+)
+
+func init() {
+	reporter.Register("cloc-json", ".cloc.json", func() reporter.Reporter { return JsonReporter{} })
+	reporter.Register("cloc-yaml", ".yaml", func() reporter.Reporter { return YamlReporter{} })
+	reporter.Register("cloc-xml", ".xml", func() reporter.Reporter { return XmlReporter{} })
+}
+
+// Version of cloc whose output schemas are written
+const Version = "1.98"
+
+const url = "github.com/colussim/GoLC"
+
+// Names of the GoLC languages which are different in cloc.
+// Several languages may have the same cloc name, their results are added.
+var Names = map[string]string{
+	"Abap":       "ABAP",
+	"Apex":       "Apex Class",
+	"C Header":   "C/C++ Header",
+	"C++ Header": "C/C++ Header",
+	"Golang":     "Go",
+	"Scss":       "SCSS",
+	"Terraform":  "HCL",
+	"Vue":        "Vuejs Component",
+}
+
+func name(language string) string {
+	if clocName, ok := Names[language]; ok {
+		return clocName
+	}
+	return language
+}
+
+type header struct {
+	ClocURL        string  `json:"cloc_url" xml:"cloc_url"`
+	ClocVersion    string  `json:"cloc_version" xml:"cloc_version"`
+	ElapsedSeconds float64 `json:"elapsed_seconds" xml:"elapsed_seconds"`
+	NFiles         int     `json:"n_files" xml:"n_files"`
+	NLines         int     `json:"n_lines" xml:"n_lines"`
+	FilesPerSecond float64 `json:"files_per_second" xml:"files_per_second"`
+	LinesPerSecond float64 `json:"lines_per_second" xml:"lines_per_second"`
+}
+
+// Counts of a language, or of a file when Language is set
+type entry struct {
+	Name     string
+	Files    int
+	Blank    int
+	Comment  int
+	Code     int
+	Language string
+}
+
+func newHeader(summary *sorter.SortedSummary) header {
+	h := header{
+		ClocURL:        url,
+		ClocVersion:    Version,
+		ElapsedSeconds: summary.Elapsed.Seconds(),
+		NFiles:         summary.TotalFiles,
+		NLines:         summary.TotalLines,
+	}
+	if summary.Elapsed > time.Duration(0) {
+		h.FilesPerSecond = float64(h.NFiles) / h.ElapsedSeconds
+		h.LinesPerSecond = float64(h.NLines) / h.ElapsedSeconds
+	}
+	return h
+}
+
+// Entries by cloc language name, in the order of the summary
+func languageEntries(summary *sorter.SortedSummary) []entry {
+	var entries []entry
+	index := make(map[string]int)
+
+	for _, r := range summary.Results {
+		n := name(r.Name)
+		i, ok := index[n]
+		if !ok {
+			i = len(entries)
+			index[n] = i
+			entries = append(entries, entry{Name: n})
+		}
+		entries[i].Files += summary.FilesByLanguage[r.Name]
+		entries[i].Blank += r.BlankLines
+		entries[i].Comment += r.Comments
+		entries[i].Code += r.CodeLines
+	}
+
+	return entries
+}
+
+func fileEntries(summary *sorter.SortedSummary) []entry {
+	entries := make([]entry, 0, len(summary.Results))
+	for _, r := range summary.Results {
+		entries = append(entries, entry{
+			Name:     r.Name,
+			Files:    1,
+			Blank:    r.BlankLines,
+			Comment:  r.Comments,
+			Code:     r.CodeLines,
+			Language: name(r.Language),
+		})
+	}
+	return entries
+}
+
+func sum(summary *sorter.SortedSummary) entry {
+	return entry{
+		Name:    "SUM",
+		Files:   summary.TotalFiles,
+		Blank:   summary.TotalBlankLines,
+		Comment: summary.TotalComments,
+		Code:    summary.TotalCodeLines,
+	}
+}
@@ -0,0 +1,89 @@
+package cloc
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+
+	"github.com/colussim/GoLC/pkg/sorter"
+)
+
+// JsonReporter writes the output of cloc --json
+type JsonReporter struct {
+}
+
+type jsonLanguage struct {
+	NFiles  int `json:"nFiles"`
+	Blank   int `json:"blank"`
+	Comment int `json:"comment"`
+	Code    int `json:"code"`
+}
+
+type jsonFile struct {
+	Blank    int    `json:"blank"`
+	Comment  int    `json:"comment"`
+	Code     int    `json:"code"`
+	Language string `json:"language"`
+}
+
+type jsonSum struct {
+	Blank   int `json:"blank"`
+	Comment int `json:"comment"`
+	Code    int `json:"code"`
+	NFiles  int `json:"nFiles"`
+}
+
+func (j JsonReporter) GenerateReportByLanguage(w io.Writer, summary *sorter.SortedSummary) error {
+	var values []interface{}
+	var keys []string
+	for _, e := range languageEntries(summary) {
+		keys = append(keys, e.Name)
+		values = append(values, jsonLanguage{NFiles: e.Files, Blank: e.Blank, Comment: e.Comment, Code: e.Code})
+	}
+	return j.write(w, summary, keys, values)
+}
+
+func (j JsonReporter) GenerateReportByFile(w io.Writer, summary *sorter.SortedSummary) error {
+	var values []interface{}
+	var keys []string
+	for _, e := range fileEntries(summary) {
+		keys = append(keys, e.Name)
+		values = append(values, jsonFile{Blank: e.Blank, Comment: e.Comment, Code: e.Code, Language: e.Language})
+	}
+	return j.write(w, summary, keys, values)
+}
+
+// Write an object with the header, the given members in order and the SUM
+func (j JsonReporter) write(w io.Writer, summary *sorter.SortedSummary, keys []string, values []interface{}) error {
+	s := sum(summary)
+	keys = append([]string{"header"}, append(keys, "SUM")...)
+	values = append([]interface{}{newHeader(summary)}, append(values, jsonSum{Blank: s.Blank, Comment: s.Comment, Code: s.Code, NFiles: s.Files})...)
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, err := json.Marshal(key)
+		if err != nil {
+			return err
+		}
+		v, err := json.Marshal(values[i])
+		if err != nil {
+			return err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+
+	var out bytes.Buffer
+	if err := json.Indent(&out, buf.Bytes(), "", "  "); err != nil {
+		return err
+	}
+	out.WriteByte('\n')
+	_, err := out.WriteTo(w)
+	return err
+}
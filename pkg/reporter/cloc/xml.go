@@ -0,0 +1,66 @@
+package cloc
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/colussim/GoLC/pkg/sorter"
+)
+
+// XmlReporter writes the output of cloc --xml
+type XmlReporter struct {
+}
+
+func (x XmlReporter) GenerateReportByLanguage(w io.Writer, summary *sorter.SortedSummary) error {
+	out := bufio.NewWriter(w)
+	if err := x.writeHeader(out, summary); err != nil {
+		return err
+	}
+	fmt.Fprint(out, "<languages>\n")
+	for _, e := range languageEntries(summary) {
+		fmt.Fprintf(out, "  <language name=%s files_count=\"%d\" blank=\"%d\" comment=\"%d\" code=\"%d\" />\n", attr(e.Name), e.Files, e.Blank, e.Comment, e.Code)
+	}
+	s := sum(summary)
+	fmt.Fprintf(out, "  <total sum_files=\"%d\" blank=\"%d\" comment=\"%d\" code=\"%d\" />\n", s.Files, s.Blank, s.Comment, s.Code)
+	fmt.Fprint(out, "</languages>\n</results>\n")
+	return out.Flush()
+}
+
+func (x XmlReporter) GenerateReportByFile(w io.Writer, summary *sorter.SortedSummary) error {
+	out := bufio.NewWriter(w)
+	if err := x.writeHeader(out, summary); err != nil {
+		return err
+	}
+	fmt.Fprint(out, "<files>\n")
+	for _, e := range fileEntries(summary) {
+		fmt.Fprintf(out, "  <file name=%s blank=\"%d\" comment=\"%d\" code=\"%d\" language=%s />\n", attr(e.Name), e.Blank, e.Comment, e.Code, attr(e.Language))
+	}
+	s := sum(summary)
+	fmt.Fprintf(out, "  <total blank=\"%d\" comment=\"%d\" code=\"%d\" />\n", s.Blank, s.Comment, s.Code)
+	fmt.Fprint(out, "</files>\n</results>\n")
+	return out.Flush()
+}
+
+func (x XmlReporter) writeHeader(out io.Writer, summary *sorter.SortedSummary) error {
+	fmt.Fprint(out, "<?xml version=\"1.0\" encoding=\"UTF-8\"?><results>\n")
+	data, err := xml.MarshalIndent(struct {
+		XMLName xml.Name `xml:"header"`
+		header
+	}{header: newHeader(summary)}, "", "  ")
+	if err != nil {
+		return err
+	}
+	out.Write(data)
+	fmt.Fprint(out, "\n")
+	return nil
+}
+
+// Quoted and escaped value of an attribute
+func attr(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return "\"" + b.String() + "\""
+}
@@ -0,0 +1,59 @@
+package cloc
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/colussim/GoLC/pkg/sorter"
+)
+
+// YamlReporter writes the output of cloc --yaml
+type YamlReporter struct {
+}
+
+func (y YamlReporter) GenerateReportByLanguage(w io.Writer, summary *sorter.SortedSummary) error {
+	out := bufio.NewWriter(w)
+	y.writeHeader(out, summary)
+	for _, e := range languageEntries(summary) {
+		fmt.Fprintf(out, "%s :\n  nFiles: %d\n  blank: %d\n  comment: %d\n  code: %d\n", yamlKey(e.Name), e.Files, e.Blank, e.Comment, e.Code)
+	}
+	y.writeSum(out, summary)
+	return out.Flush()
+}
+
+func (y YamlReporter) GenerateReportByFile(w io.Writer, summary *sorter.SortedSummary) error {
+	out := bufio.NewWriter(w)
+	y.writeHeader(out, summary)
+	for _, e := range fileEntries(summary) {
+		fmt.Fprintf(out, "%s :\n  blank: %d\n  comment: %d\n  code: %d\n  language: %s\n", yamlKey(e.Name), e.Blank, e.Comment, e.Code, yamlKey(e.Language))
+	}
+	y.writeSum(out, summary)
+	return out.Flush()
+}
+
+func (y YamlReporter) writeHeader(out io.Writer, summary *sorter.SortedSummary) {
+	h := newHeader(summary)
+	fmt.Fprintf(out, "---\n# %s\nheader :\n", url)
+	fmt.Fprintf(out, "  cloc_url           : %s\n", h.ClocURL)
+	fmt.Fprintf(out, "  cloc_version       : %s\n", h.ClocVersion)
+	fmt.Fprintf(out, "  elapsed_seconds    : %g\n", h.ElapsedSeconds)
+	fmt.Fprintf(out, "  n_files            : %d\n", h.NFiles)
+	fmt.Fprintf(out, "  n_lines            : %d\n", h.NLines)
+	fmt.Fprintf(out, "  files_per_second   : %g\n", h.FilesPerSecond)
+	fmt.Fprintf(out, "  lines_per_second   : %g\n", h.LinesPerSecond)
+}
+
+func (y YamlReporter) writeSum(out io.Writer, summary *sorter.SortedSummary) {
+	s := sum(summary)
+	fmt.Fprintf(out, "SUM:\n  blank: %d\n  comment: %d\n  code: %d\n  nFiles: %d\n", s.Blank, s.Comment, s.Code, s.Files)
+}
+
+// Quote the keys and values which are not plain YAML scalars, like paths with ": "
+func yamlKey(s string) string {
+	if s == "" || strings.ContainsAny(s, ":#'\"{}[],&*!|>%@`\\") || strings.TrimSpace(s) != s || strings.HasPrefix(s, "-") || strings.HasPrefix(s, "?") {
+		return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+	}
+	return s
+}
@@ -0,0 +1,99 @@
+package csv
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+
+	"github.com/colussim/GoLC/pkg/reporter"
+	"github.com/colussim/GoLC/pkg/sorter"
+)
+
+func init() {
+	reporter.Register("csv", ".csv", func() reporter.Reporter { return CsvReporter{Comma: ',', Totals: true} })
+	reporter.Register("tsv", ".tsv", func() reporter.Reporter { return CsvReporter{Comma: '\t', Totals: true} })
+	// Without the last row of totals, for the tools which sum the rows themselves.
+	// Their extensions differ so that they can be written with the reports with totals.
+	reporter.Register("csv-nototals", ".nototals.csv", func() reporter.Reporter { return CsvReporter{Comma: ','} })
+	reporter.Register("tsv-nototals", ".nototals.tsv", func() reporter.Reporter { return CsvReporter{Comma: '\t'} })
+}
+
+// CsvReporter writes one row per language or file, fields containing the separator,
+// quotes or new lines are quoted
+type CsvReporter struct {
+	Comma  rune // Field separator
+	Totals bool // Add a last row with the totals
+}
+
+var (
+	LanguageHeader = []string{"Language", "Files", "Lines", "BlankLines", "Comments", "CodeLines"}
+	FileHeader     = []string{"File", "Lines", "BlankLines", "Comments", "CodeLines"}
+)
+
+func (c CsvReporter) GenerateReportByLanguage(w io.Writer, summary *sorter.SortedSummary) error {
+	records := [][]string{LanguageHeader}
+
+	for _, r := range summary.Results {
+		records = append(records, []string{
+			r.Name,
+			strconv.Itoa(summary.FilesByLanguage[r.Name]),
+			strconv.Itoa(r.Lines),
+			strconv.Itoa(r.BlankLines),
+			strconv.Itoa(r.Comments),
+			strconv.Itoa(r.CodeLines),
+		})
+	}
+
+	if c.Totals {
+		records = append(records, []string{
+			"Total",
+			strconv.Itoa(summary.TotalFiles),
+			strconv.Itoa(summary.TotalLines),
+			strconv.Itoa(summary.TotalBlankLines),
+			strconv.Itoa(summary.TotalComments),
+			strconv.Itoa(summary.TotalCodeLines),
+		})
+	}
+
+	return c.write(w, records)
+}
+
+func (c CsvReporter) GenerateReportByFile(w io.Writer, summary *sorter.SortedSummary) error {
+	records := [][]string{FileHeader}
+
+	for _, r := range summary.Results {
+		records = append(records, []string{
+			r.Name,
+			strconv.Itoa(r.Lines),
+			strconv.Itoa(r.BlankLines),
+			strconv.Itoa(r.Comments),
+			strconv.Itoa(r.CodeLines),
+		})
+	}
+
+	if c.Totals {
+		records = append(records, []string{
+			"Total",
+			strconv.Itoa(summary.TotalLines),
+			strconv.Itoa(summary.TotalBlankLines),
+			strconv.Itoa(summary.TotalComments),
+			strconv.Itoa(summary.TotalCodeLines),
+		})
+	}
+
+	return c.write(w, records)
+}
+
+func (c CsvReporter) write(w io.Writer, records [][]string) error {
+	writer := NewWriter(w, c.Comma)
+	return writer.WriteAll(records)
+}
+
+// NewWriter returns a csv writer using comma as field separator, ',' when it is 0
+func NewWriter(w io.Writer, comma rune) *csv.Writer {
+	writer := csv.NewWriter(w)
+	if comma != 0 {
+		writer.Comma = comma
+	}
+	return writer
+}
@@ -2,17 +2,17 @@ package json
 
 import (
 	"encoding/json"
-	"fmt"
-	"os"
-	"path/filepath"
-	"strings"
+	"io"
 
+	"github.com/colussim/GoLC/pkg/reporter"
 	"github.com/colussim/GoLC/pkg/sorter"
 )
 
+func init() {
+	reporter.Register("json", ".json", func() reporter.Reporter { return JsonReporter{} })
+}
+
 type JsonReporter struct {
-	OutputName string
-	OutputPath string
 }
 
 type languageResult struct {
@@ -41,7 +41,7 @@ type report struct {
 	Results         interface{}
 }
 
-func (j JsonReporter) GenerateReportByLanguage(summary *sorter.SortedSummary) error {
+func (j JsonReporter) GenerateReportByLanguage(w io.Writer, summary *sorter.SortedSummary) error {
 	jsonReport := &report{
 		TotalFiles:      summary.TotalFiles,
 		TotalLines:      summary.TotalLines,
@@ -62,10 +62,10 @@ func (j JsonReporter) GenerateReportByLanguage(summary *sorter.SortedSummary) er
 		})
 	}
 
-	return j.writeJson(jsonReport)
+	return j.writeJson(w, jsonReport)
 }
 
-func (j JsonReporter) GenerateReportByFile(summary *sorter.SortedSummary) error {
+func (j JsonReporter) GenerateReportByFile(w io.Writer, summary *sorter.SortedSummary) error {
 	jsonReport := &report{
 		TotalLines:      summary.TotalLines,
 		TotalBlankLines: summary.TotalBlankLines,
@@ -84,26 +84,11 @@ func (j JsonReporter) GenerateReportByFile(summary *sorter.SortedSummary) error
 		})
 	}
 
-	return j.writeJson(jsonReport)
+	return j.writeJson(w, jsonReport)
 }
 
-func (j JsonReporter) writeJson(jsonReport *report) error {
-	file, err := json.MarshalIndent(jsonReport, "", "  ")
-	if err != nil {
-		return err
-	}
-
-	outputName := strings.Replace(j.OutputName, "/", "_", -1)
-	if !strings.HasSuffix(outputName, ".json") {
-		outputName += ".json"
-	}
-
-	path := filepath.Join(j.OutputPath, outputName)
-	if err := os.WriteFile(path, file, 0644); err != nil {
-		return err
-	}
-
-	fmt.Printf("\n\t✅ json report exported to %s\n", path)
-
-	return nil
+func (j JsonReporter) writeJson(w io.Writer, jsonReport *report) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(jsonReport)
 }
@@ -0,0 +1,120 @@
+package markdown
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/colussim/GoLC/pkg/reporter"
+	"github.com/colussim/GoLC/pkg/sorter"
+	"github.com/colussim/GoLC/pkg/utils"
+)
+
+func init() {
+	reporter.Register("markdown", ".md", func() reporter.Reporter { return MarkdownReporter{Top: 20} })
+}
+
+// MarkdownReporter writes GitHub flavored markdown tables
+type MarkdownReporter struct {
+	Top int // Number of files of the report by file, 0 for all
+}
+
+func (m MarkdownReporter) GenerateReportByLanguage(w io.Writer, summary *sorter.SortedSummary) error {
+	rows := make([][]string, 0, len(summary.Results)+1)
+	for _, r := range summary.Results {
+		rows = append(rows, []string{
+			r.Name,
+			strconv.Itoa(summary.FilesByLanguage[r.Name]),
+			strconv.Itoa(r.BlankLines),
+			strconv.Itoa(r.Comments),
+			utils.FormatCodeLines(float64(r.CodeLines)),
+		})
+	}
+	rows = append(rows, []string{
+		"**Total**",
+		strconv.Itoa(summary.TotalFiles),
+		strconv.Itoa(summary.TotalBlankLines),
+		strconv.Itoa(summary.TotalComments),
+		"**" + utils.FormatCodeLines(float64(summary.TotalCodeLines)) + "**",
+	})
+
+	fmt.Fprint(w, "### Lines of code by language\n\n")
+	return WriteTable(w, []string{"Language", "Files", "Blank lines", "Comments", "Code lines"}, "lrrrr", rows)
+}
+
+func (m MarkdownReporter) GenerateReportByFile(w io.Writer, summary *sorter.SortedSummary) error {
+	results := summary.Results
+	title := "### Lines of code by file\n\n"
+	if m.Top > 0 && len(results) > m.Top {
+		results = results[:m.Top]
+		title = fmt.Sprintf("### Top %d of %d files\n\n", m.Top, len(summary.Results))
+	}
+
+	rows := make([][]string, 0, len(results)+1)
+	for _, r := range results {
+		rows = append(rows, []string{
+			Code(r.Name),
+			r.Language,
+			strconv.Itoa(r.BlankLines),
+			strconv.Itoa(r.Comments),
+			utils.FormatCodeLines(float64(r.CodeLines)),
+		})
+	}
+	rows = append(rows, []string{
+		"**Total**",
+		"",
+		strconv.Itoa(summary.TotalBlankLines),
+		strconv.Itoa(summary.TotalComments),
+		"**" + utils.FormatCodeLines(float64(summary.TotalCodeLines)) + "**",
+	})
+
+	fmt.Fprint(w, title)
+	return WriteTable(w, []string{"File", "Language", "Blank lines", "Comments", "Code lines"}, "llrrr", rows)
+}
+
+// Write a table, align has one letter per column : l for left, r for right
+func WriteTable(w io.Writer, header []string, align string, rows [][]string) error {
+	var b strings.Builder
+
+	writeRow(&b, header)
+	separators := make([]string, len(header))
+	for i := range separators {
+		separators[i] = "---"
+		if i < len(align) && align[i] == 'r' {
+			separators[i] = "---:"
+		}
+	}
+	writeRow(&b, separators)
+	for _, row := range rows {
+		writeRow(&b, row)
+	}
+	b.WriteString("\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeRow(b *strings.Builder, cells []string) {
+	b.WriteString("|")
+	for _, cell := range cells {
+		b.WriteString(" ")
+		b.WriteString(Escape(cell))
+		b.WriteString(" |")
+	}
+	b.WriteString("\n")
+}
+
+// Escape the characters which break a table cell
+func Escape(s string) string {
+	s = strings.ReplaceAll(s, "|", "\\|")
+	return strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ").Replace(s)
+}
+
+// Format a path as inline code, so that _ and * are not read as markdown
+func Code(s string) string {
+	if s == "" || strings.Contains(s, "`") {
+		return s
+	}
+	return "`" + s + "`"
+}
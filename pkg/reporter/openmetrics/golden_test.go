@@ -0,0 +1,87 @@
+package openmetrics
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/colussim/GoLC/pkg/reporter"
+	"github.com/colussim/GoLC/pkg/sorter"
+)
+
+var update = flag.Bool("update", false, "Rewrite the golden files of testdata")
+
+// Summary by language, with languages renamed and added by cloc
+var languageSummary = &sorter.SortedSummary{
+	Results: []sorter.Result{
+		{Name: "Golang", Lines: 120, CodeLines: 90, BlankLines: 20, Comments: 10},
+		{Name: "C Header", Lines: 30, CodeLines: 25, BlankLines: 3, Comments: 2},
+		{Name: "C++ Header", Lines: 12, CodeLines: 10, BlankLines: 1, Comments: 1},
+	},
+	FilesByLanguage: map[string]int{"Golang": 3, "C Header": 2, "C++ Header": 1},
+	TotalFiles:      6,
+	TotalLines:      162,
+	TotalCodeLines:  125,
+	TotalBlankLines: 24,
+	TotalComments:   13,
+	Elapsed:         2 * time.Second,
+}
+
+// Summary by file, with a name to quote
+var fileSummary = &sorter.SortedSummary{
+	Results: []sorter.Result{
+		{Name: "cmd/main.go", Language: "Golang", Lines: 80, CodeLines: 60, BlankLines: 12, Comments: 8},
+		{Name: "include/a, \"b\".h", Language: "C Header", Lines: 30, CodeLines: 25, BlankLines: 3, Comments: 2},
+	},
+	TotalFiles:      2,
+	TotalLines:      110,
+	TotalCodeLines:  85,
+	TotalBlankLines: 15,
+	TotalComments:   10,
+	Elapsed:         time.Second,
+}
+
+func TestGolden(t *testing.T) {
+	tests := []struct {
+		name     string
+		reporter reporter.Reporter
+		byFile   bool
+	}{
+		{"by_language", OpenMetricsReporter{}, false},
+		{"by_file", OpenMetricsReporter{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			var err error
+			if tt.byFile {
+				err = tt.reporter.GenerateReportByFile(&buf, fileSummary)
+			} else {
+				err = tt.reporter.GenerateReportByLanguage(&buf, languageSummary)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			golden := filepath.Join("testdata", tt.name+".golden")
+			if *update {
+				if err := os.MkdirAll("testdata", 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(golden, buf.Bytes(), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(buf.Bytes(), want) {
+				t.Errorf("%s report differs from %s:\n%s", tt.name, golden, buf.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package openmetrics
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/colussim/GoLC/pkg/reporter"
+	"github.com/colussim/GoLC/pkg/sorter"
+)
+
+func init() {
+	reporter.Register("openmetrics", ".prom", func() reporter.Reporter { return OpenMetricsReporter{} })
+}
+
+// Content type of the exposition format
+const ContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+
+type Label struct {
+	Name  string
+	Value string
+}
+
+type Sample struct {
+	Labels []Label
+	Value  float64
+}
+
+// Write a gauge metric family
+func WriteGauge(w io.Writer, name, help string, samples []Sample) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s gauge\n", name, help, name)
+	for _, s := range samples {
+		b.WriteString(name)
+		if len(s.Labels) > 0 {
+			b.WriteString("{")
+			for i, l := range s.Labels {
+				if i > 0 {
+					b.WriteString(",")
+				}
+				fmt.Fprintf(&b, "%s=\"%s\"", l.Name, escape(l.Value))
+			}
+			b.WriteString("}")
+		}
+		b.WriteString(" ")
+		b.WriteString(strconv.FormatFloat(s.Value, 'f', -1, 64))
+		b.WriteString("\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// Write the end of the exposition
+func WriteEOF(w io.Writer) error {
+	_, err := io.WriteString(w, "# EOF\n")
+	return err
+}
+
+func escape(s string) string {
+	return strings.NewReplacer("\\", "\\\\", "\"", "\\\"", "\n", "\\n").Replace(s)
+}
+
+// OpenMetricsReporter writes the results as gauges, in a file readable by the
+// textfile collector of the node exporter
+type OpenMetricsReporter struct {
+}
+
+// Counts of a language or a file
+type counts struct {
+	Labels                                   []Label
+	Files, Lines, BlankLines, Comments, Code int
+}
+
+func (o OpenMetricsReporter) GenerateReportByLanguage(w io.Writer, summary *sorter.SortedSummary) error {
+	var results []counts
+	for _, r := range summary.Results {
+		results = append(results, counts{
+			Labels:     []Label{{"language", r.Name}},
+			Files:      summary.FilesByLanguage[r.Name],
+			Lines:      r.Lines,
+			BlankLines: r.BlankLines,
+			Comments:   r.Comments,
+			Code:       r.CodeLines,
+		})
+	}
+	return o.write(w, results, true)
+}
+
+func (o OpenMetricsReporter) GenerateReportByFile(w io.Writer, summary *sorter.SortedSummary) error {
+	var results []counts
+	for _, r := range summary.Results {
+		results = append(results, counts{
+			Labels:     []Label{{"file", r.Name}, {"language", r.Language}},
+			Lines:      r.Lines,
+			BlankLines: r.BlankLines,
+			Comments:   r.Comments,
+			Code:       r.CodeLines,
+		})
+	}
+	return o.write(w, results, false)
+}
+
+func (o OpenMetricsReporter) write(w io.Writer, results []counts, withFiles bool) error {
+	families := []struct {
+		name, help string
+		value      func(c counts) int
+	}{
+		{"golc_files", "Number of files", func(c counts) int { return c.Files }},
+		{"golc_lines", "Number of lines", func(c counts) int { return c.Lines }},
+		{"golc_blank_lines", "Number of blank lines", func(c counts) int { return c.BlankLines }},
+		{"golc_comment_lines", "Number of comment lines", func(c counts) int { return c.Comments }},
+		{"golc_code_lines", "Number of code lines", func(c counts) int { return c.Code }},
+	}
+	for _, f := range families {
+		// A file has no file count
+		if f.name == "golc_files" && !withFiles {
+			continue
+		}
+		samples := make([]Sample, 0, len(results))
+		for _, c := range results {
+			samples = append(samples, Sample{Labels: c.Labels, Value: float64(f.value(c))})
+		}
+		if err := WriteGauge(w, f.name, f.help, samples); err != nil {
+			return err
+		}
+	}
+
+	return WriteEOF(w)
+}
@@ -1,18 +1,23 @@
 package prompt
 
 import (
-	"os"
+	"io"
 	"strconv"
 
+	"github.com/colussim/GoLC/pkg/reporter"
 	"github.com/colussim/GoLC/pkg/sorter"
 	"github.com/olekukonko/tablewriter"
 )
 
+func init() {
+	reporter.Register("prompt", "", func() reporter.Reporter { return PromptReporter{} })
+}
+
 type PromptReporter struct {
 }
 
-func (p PromptReporter) GenerateReportByLanguage(summary *sorter.SortedSummary) error {
-	table := tablewriter.NewWriter(os.Stdout)
+func (p PromptReporter) GenerateReportByLanguage(w io.Writer, summary *sorter.SortedSummary) error {
+	table := tablewriter.NewWriter(w)
 	table.SetHeader([]string{
 		"Language",
 		"Files",
@@ -49,8 +54,8 @@ func (p PromptReporter) GenerateReportByLanguage(summary *sorter.SortedSummary)
 	return nil
 }
 
-func (p PromptReporter) GenerateReportByFile(summary *sorter.SortedSummary) error {
-	table := tablewriter.NewWriter(os.Stdout)
+func (p PromptReporter) GenerateReportByFile(w io.Writer, summary *sorter.SortedSummary) error {
+	table := tablewriter.NewWriter(w)
 	table.SetHeader([]string{
 		"Path",
 		"Lines",
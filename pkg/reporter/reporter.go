@@ -1,8 +1,61 @@
 package reporter
 
-import "github.com/colussim/GoLC/pkg/sorter"
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
 
+	"github.com/colussim/GoLC/pkg/sorter"
+)
+
+// A Reporter writes a sorted summary in its format to w
 type Reporter interface {
-	GenerateReportByLanguage(summary *sorter.SortedSummary) error
-	GenerateReportByFile(summary *sorter.SortedSummary) error
+	GenerateReportByLanguage(w io.Writer, summary *sorter.SortedSummary) error
+	GenerateReportByFile(w io.Writer, summary *sorter.SortedSummary) error
+}
+
+// Registered report format.
+// Extension is the file extension of the reports, with its leading dot.
+// An empty Extension means the report is written to the terminal.
+type Format struct {
+	Name      string
+	Extension string
+	New       func() Reporter
+}
+
+var (
+	mu      sync.RWMutex
+	formats = map[string]Format{}
+)
+
+// Register a report format by name. It replaces a format registered with the same name.
+// Third-party reporters usually call it from an init function.
+func Register(name, extension string, newReporter func() Reporter) {
+	mu.Lock()
+	defer mu.Unlock()
+	formats[name] = Format{Name: name, Extension: extension, New: newReporter}
+}
+
+// Lookup returns the format registered with name
+func Lookup(name string) (Format, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+	format, ok := formats[name]
+	if !ok {
+		return Format{}, fmt.Errorf("%s report format not supported", name)
+	}
+	return format, nil
+}
+
+// Names returns the sorted names of the registered formats
+func Names() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	names := make([]string, 0, len(formats))
+	for name := range formats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
 }
@@ -0,0 +1,83 @@
+package retry
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Policy of the retries of a failed operation
+type Policy struct {
+	Retries int           // Number of retries after the first attempt
+	Backoff time.Duration // Wait before the first retry, doubled for every next one
+}
+
+// Wait before the given retry (1 for the first one)
+func (p Policy) delay(retry int) time.Duration {
+	return p.Backoff << (retry - 1)
+}
+
+// Run fn until it succeeds, the retries are exhausted, retryable returns false or ctx is done.
+// The number of attempts is returned with the last error.
+func (p Policy) Do(ctx context.Context, fn func(ctx context.Context) error, retryable func(error) bool) (int, error) {
+	attempt := 1
+	for {
+		err := fn(ctx)
+		if err == nil || attempt > p.Retries || ctx.Err() != nil || (retryable != nil && !retryable(err)) {
+			return attempt, err
+		}
+
+		select {
+		case <-time.After(p.delay(attempt)):
+		case <-ctx.Done():
+			return attempt, err
+		}
+		attempt++
+	}
+}
+
+// Transport retries the idempotent requests which fail with a network error,
+// 429 Too Many Requests or a 5xx status.
+type Transport struct {
+	Base   http.RoundTripper
+	Policy Policy
+}
+
+func NewTransport(base http.RoundTripper, policy Policy) *Transport {
+	return &Transport{Base: base, Policy: policy}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return t.Base.RoundTrip(req)
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := t.Base.RoundTrip(req)
+		if attempt > t.Policy.Retries || !shouldRetry(resp, err) {
+			return resp, err
+		}
+
+		wait := t.Policy.delay(attempt)
+		if resp != nil {
+			if seconds, perr := strconv.Atoi(resp.Header.Get("Retry-After")); perr == nil && seconds > 0 && time.Duration(seconds)*time.Second <= time.Minute {
+				wait = time.Duration(seconds) * time.Second
+			}
+			resp.Body.Close()
+		}
+
+		select {
+		case <-time.After(wait):
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	}
+}
+
+func shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+}
@@ -0,0 +1,105 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var errTransient = errors.New("transient")
+
+func TestDoRetries(t *testing.T) {
+	p := Policy{Retries: 2, Backoff: time.Millisecond}
+
+	calls := 0
+	attempts, err := p.Do(context.Background(), func(ctx context.Context) error {
+		calls++
+		if calls < 3 {
+			return errTransient
+		}
+		return nil
+	}, nil)
+	if err != nil || attempts != 3 {
+		t.Errorf("Do = %d, %v, want 3 attempts without error", attempts, err)
+	}
+
+	calls = 0
+	attempts, err = p.Do(context.Background(), func(ctx context.Context) error {
+		calls++
+		return errTransient
+	}, nil)
+	if err != errTransient || attempts != 3 {
+		t.Errorf("Do = %d, %v, want 3 attempts with the last error", attempts, err)
+	}
+}
+
+func TestDoNotRetryable(t *testing.T) {
+	p := Policy{Retries: 5, Backoff: time.Millisecond}
+	permanent := errors.New("not found")
+
+	attempts, err := p.Do(context.Background(), func(ctx context.Context) error {
+		return permanent
+	}, func(err error) bool { return err == errTransient })
+	if err != permanent || attempts != 1 {
+		t.Errorf("Do = %d, %v, want 1 attempt", attempts, err)
+	}
+}
+
+func TestDoCancel(t *testing.T) {
+	p := Policy{Retries: 5, Backoff: time.Hour}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan int)
+	go func() {
+		attempts, _ := p.Do(ctx, func(ctx context.Context) error { return errTransient }, nil)
+		done <- attempts
+	}()
+	cancel()
+
+	select {
+	case attempts := <-done:
+		if attempts != 1 {
+			t.Errorf("%d attempts after the cancellation, want 1", attempts)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Do does not stop when its context is cancelled")
+	}
+}
+
+func TestTransport(t *testing.T) {
+	var calls atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: NewTransport(http.DefaultTransport, Policy{Retries: 2, Backoff: time.Millisecond})}
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || calls.Load() != 3 {
+		t.Errorf("status %d after %d calls, want 200 after 3", resp.StatusCode, calls.Load())
+	}
+
+	// Only the idempotent requests are retried
+	calls.Store(0)
+	resp, err = client.Post(server.URL, "text/plain", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if calls.Load() != 1 {
+		t.Errorf("POST sent %d times, want 1", calls.Load())
+	}
+}
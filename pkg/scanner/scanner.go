@@ -2,6 +2,7 @@ package scanner
 
 import (
 	"bufio"
+	"context"
 	"os"
 	"strings"
 
@@ -12,6 +13,8 @@ import (
 
 type Scanner struct {
 	SupportedLanguages language.Languages
+	Quiet              bool                               // Do not show the progress bar
+	OnFile             func(path string, done, total int) // Called after each scanned file
 }
 
 type scanResult struct {
@@ -29,15 +32,31 @@ func NewScanner(languages language.Languages) *Scanner {
 }
 
 func (sc *Scanner) Scan(files []analyzer.FileMetadata) ([]scanResult, error) {
+	return sc.ScanContext(context.Background(), files)
+}
+
+// ScanContext is Scan stopping at the first file after ctx is done
+func (sc *Scanner) ScanContext(ctx context.Context, files []analyzer.FileMetadata) ([]scanResult, error) {
 	var results []scanResult
-	progress := sc.createProgressbar(len(files))
+	var progress *progressbar.ProgressBar
+	if !sc.Quiet {
+		progress = sc.createProgressbar(len(files))
+	}
 
-	for _, file := range files {
+	for i, file := range files {
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
 		result, err := sc.scanFile(file)
 		if err != nil {
 			return results, err
 		}
-		progress.Add(1)
+		if progress != nil {
+			progress.Add(1)
+		}
+		if sc.OnFile != nil {
+			sc.OnFile(file.FilePath, i+1, len(files))
+		}
 		results = append(results, result)
 	}
 
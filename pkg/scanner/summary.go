@@ -9,6 +9,7 @@ type LanguageResult struct {
 
 type FileResult struct {
 	Path       string
+	Language   string
 	Lines      int
 	CodeLines  int
 	BlankLines int
@@ -51,6 +52,7 @@ func (sc *Scanner) Summary(results []scanResult) *Summary {
 
 		summary.Files = append(summary.Files, FileResult{
 			Path:       result.Metadata.FilePath,
+			Language:   language,
 			Lines:      result.Lines,
 			CodeLines:  result.CodeLines,
 			BlankLines: result.BlankLines,
@@ -0,0 +1,153 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type Status string
+
+// Status of a job
+const (
+	Pending   Status = "pending"
+	Running   Status = "running"
+	Done      Status = "done"
+	Failed    Status = "failed"
+	Cancelled Status = "cancelled"
+)
+
+// Job is a unit of work run by a worker
+type Job struct {
+	Name string
+	Run  func(ctx context.Context) error
+}
+
+type Result struct {
+	Name     string
+	Status   Status
+	Err      error
+	Duration time.Duration
+}
+
+// Scheduler runs jobs with a fixed number of workers pulling from a queue
+type Scheduler struct {
+	workers int
+
+	running   atomic.Int64
+	done      atomic.Int64
+	failed    atomic.Int64
+	cancelled atomic.Int64
+}
+
+func New(workers int) *Scheduler {
+	if workers < 1 {
+		workers = 1
+	}
+	return &Scheduler{workers: workers}
+}
+
+// Counters of the jobs, safe to read while Run is in progress
+func (s *Scheduler) Running() int   { return int(s.running.Load()) }
+func (s *Scheduler) Done() int      { return int(s.done.Load()) }
+func (s *Scheduler) Failed() int    { return int(s.failed.Load()) }
+func (s *Scheduler) Cancelled() int { return int(s.cancelled.Load()) }
+
+// Run all the jobs and return their results in the order of the jobs.
+// When ctx is cancelled, no job is started anymore and the jobs which stop with an error are cancelled.
+// onResult is called for every finished job, one call at a time.
+func (s *Scheduler) Run(ctx context.Context, jobs []Job, onResult func(Result)) []Result {
+	results := make([]Result, len(jobs))
+	for i, job := range jobs {
+		results[i] = Result{Name: job.Name, Status: Pending}
+	}
+
+	queue := make(chan int)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+
+	report := func(i int, result Result) {
+		mu.Lock()
+		defer mu.Unlock()
+		results[i] = result
+		if onResult != nil {
+			onResult(result)
+		}
+	}
+
+	for w := 0; w < s.workers && w < len(jobs); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range queue {
+				report(i, s.runJob(ctx, jobs[i]))
+			}
+		}()
+	}
+
+	// Feed the queue until all jobs are taken or the run is cancelled
+	next := 0
+feed:
+	for ; next < len(jobs); next++ {
+		select {
+		case queue <- next:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(queue)
+	wg.Wait()
+
+	for i := next; i < len(jobs); i++ {
+		s.cancelled.Add(1)
+		report(i, Result{Name: jobs[i].Name, Status: Cancelled, Err: ctx.Err()})
+	}
+
+	return results
+}
+
+func (s *Scheduler) runJob(ctx context.Context, job Job) (result Result) {
+	result.Name = job.Name
+	start := time.Now()
+
+	if ctx.Err() != nil {
+		s.cancelled.Add(1)
+		result.Status = Cancelled
+		result.Err = ctx.Err()
+		return result
+	}
+
+	s.running.Add(1)
+	defer s.running.Add(-1)
+
+	err := call(ctx, job)
+	result.Duration = time.Since(start)
+	result.Err = err
+
+	switch {
+	case err != nil && ctx.Err() != nil:
+		// Stopped by the cancellation of the run
+		s.cancelled.Add(1)
+		result.Status = Cancelled
+	case err != nil:
+		s.failed.Add(1)
+		result.Status = Failed
+	default:
+		s.done.Add(1)
+		result.Status = Done
+	}
+
+	return result
+}
+
+// Run a job, turning a panic into an error so that one repository does not stop the others
+func call(ctx context.Context, job Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return job.Run(ctx)
+}
@@ -0,0 +1,109 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunLimitsWorkers(t *testing.T) {
+	const workers = 3
+	var running, peak atomic.Int64
+
+	jobs := make([]Job, 20)
+	for i := range jobs {
+		jobs[i] = Job{Name: fmt.Sprint(i), Run: func(ctx context.Context) error {
+			n := running.Add(1)
+			defer running.Add(-1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			return nil
+		}}
+	}
+
+	s := New(workers)
+	results := s.Run(context.Background(), jobs, nil)
+
+	if p := peak.Load(); p > workers {
+		t.Errorf("%d jobs ran at the same time, want at most %d", p, workers)
+	}
+	if s.Done() != len(jobs) {
+		t.Errorf("Done() = %d, want %d", s.Done(), len(jobs))
+	}
+	for i, result := range results {
+		if result.Name != jobs[i].Name || result.Status != Done {
+			t.Errorf("result %d = %s %s, want %s done", i, result.Name, result.Status, jobs[i].Name)
+		}
+	}
+}
+
+func TestRunCountsFailuresAndPanics(t *testing.T) {
+	jobs := []Job{
+		{Name: "ok", Run: func(ctx context.Context) error { return nil }},
+		{Name: "error", Run: func(ctx context.Context) error { return errors.New("clone failed") }},
+		{Name: "panic", Run: func(ctx context.Context) error { panic("boom") }},
+	}
+
+	var calls int
+	s := New(2)
+	results := s.Run(context.Background(), jobs, func(Result) { calls++ })
+
+	want := []Status{Done, Failed, Failed}
+	for i, result := range results {
+		if result.Status != want[i] {
+			t.Errorf("%s: status %s, want %s", result.Name, result.Status, want[i])
+		}
+	}
+	if results[2].Err == nil {
+		t.Error("the panic of a job is not returned as an error")
+	}
+	if s.Done() != 1 || s.Failed() != 2 || s.Cancelled() != 0 {
+		t.Errorf("counters done %d failed %d cancelled %d, want 1 2 0", s.Done(), s.Failed(), s.Cancelled())
+	}
+	if calls != len(jobs) {
+		t.Errorf("onResult called %d times, want %d", calls, len(jobs))
+	}
+}
+
+func TestRunCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	started := make(chan struct{})
+	jobs := make([]Job, 10)
+	for i := range jobs {
+		jobs[i] = Job{Name: fmt.Sprint(i), Run: func(ctx context.Context) error {
+			started <- struct{}{}
+			<-ctx.Done()
+			return ctx.Err()
+		}}
+	}
+
+	s := New(2)
+	go func() {
+		<-started
+		<-started
+		cancel()
+	}()
+	results := s.Run(ctx, jobs, nil)
+
+	if s.Cancelled() != len(jobs) {
+		t.Errorf("Cancelled() = %d, want %d", s.Cancelled(), len(jobs))
+	}
+	if s.Running() != 0 {
+		t.Errorf("Running() = %d after Run, want 0", s.Running())
+	}
+	for _, result := range results {
+		if result.Status != Cancelled {
+			t.Errorf("%s: status %s, want %s", result.Name, result.Status, Cancelled)
+		}
+	}
+}
@@ -26,6 +26,7 @@ func (f FileSorter) OrderByLanguage(summary *scanner.Summary) *SortedSummary {
 
 	return &SortedSummary{
 		Results:         results,
+		TotalFiles:      summary.TotalFiles,
 		TotalLines:      summary.TotalLines,
 		TotalCodeLines:  summary.TotalCodeLines,
 		TotalBlankLines: summary.TotalBlankLines,
@@ -40,6 +41,7 @@ func (f FileSorter) OrderByCodeLines(summary *scanner.Summary) *SortedSummary {
 
 	return &SortedSummary{
 		Results:         results,
+		TotalFiles:      summary.TotalFiles,
 		TotalLines:      summary.TotalLines,
 		TotalCodeLines:  summary.TotalCodeLines,
 		TotalBlankLines: summary.TotalBlankLines,
@@ -54,6 +56,7 @@ func (f FileSorter) OrderByLines(summary *scanner.Summary) *SortedSummary {
 
 	return &SortedSummary{
 		Results:         results,
+		TotalFiles:      summary.TotalFiles,
 		TotalLines:      summary.TotalLines,
 		TotalCodeLines:  summary.TotalCodeLines,
 		TotalBlankLines: summary.TotalBlankLines,
@@ -68,6 +71,7 @@ func (f FileSorter) OrderByComments(summary *scanner.Summary) *SortedSummary {
 
 	return &SortedSummary{
 		Results:         results,
+		TotalFiles:      summary.TotalFiles,
 		TotalLines:      summary.TotalLines,
 		TotalCodeLines:  summary.TotalCodeLines,
 		TotalBlankLines: summary.TotalBlankLines,
@@ -82,6 +86,7 @@ func (f FileSorter) OrderByBlankLines(summary *scanner.Summary) *SortedSummary {
 
 	return &SortedSummary{
 		Results:         results,
+		TotalFiles:      summary.TotalFiles,
 		TotalLines:      summary.TotalLines,
 		TotalCodeLines:  summary.TotalCodeLines,
 		TotalBlankLines: summary.TotalBlankLines,
@@ -95,6 +100,7 @@ func (f FileSorter) getResults(summary *scanner.Summary) []Result {
 	for _, result := range summary.Files {
 		results = append(results, Result{
 			Name:       result.Path,
+			Language:   result.Language,
 			Lines:      result.Lines,
 			CodeLines:  result.CodeLines,
 			BlankLines: result.BlankLines,
@@ -2,12 +2,14 @@ package sorter
 
 import (
 	"sort"
+	"time"
 
 	"github.com/colussim/GoLC/pkg/scanner"
 )
 
 type Result struct {
 	Name       string
+	Language   string // Language of a file, empty in the results by language
 	Lines      int
 	CodeLines  int
 	BlankLines int
@@ -22,6 +24,7 @@ type SortedSummary struct {
 	TotalCodeLines  int
 	TotalBlankLines int
 	TotalComments   int
+	Elapsed         time.Duration // Duration of the scan, set by goloc
 }
 
 type Sorter interface {
@@ -0,0 +1,128 @@
+package store
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Layout of the time in the name of the archives of the Saves directory
+const archiveTimeLayout = "2006-01-02_15-04-05"
+
+// Save the run of a results directory archived by a previous run in the Saves directory.
+// Without its start in config/run.json, the time of the run is the time in the archive name.
+func (s *Store) SaveArchive(archivePath string) (int64, error) {
+	runTime, err := archiveTime(archivePath)
+	if err != nil {
+		return 0, err
+	}
+
+	directory, err := os.MkdirTemp("", "golc-archive-")
+	if err != nil {
+		return 0, err
+	}
+	defer os.RemoveAll(directory)
+
+	if err := extract(archivePath, directory); err != nil {
+		return 0, fmt.Errorf("error extracting %s: %w", archivePath, err)
+	}
+
+	return s.saveDirectory(directory, runTime)
+}
+
+// Time of an archive, from the end of its name or else its modification time
+func archiveTime(archivePath string) (time.Time, error) {
+	info, err := os.Stat(archivePath)
+	if err != nil {
+		return time.Time{}, err
+	}
+	name := strings.TrimSuffix(filepath.Base(archivePath), ".zip")
+	if len(name) > len(archiveTimeLayout) {
+		if t, err := time.ParseInLocation(archiveTimeLayout, name[len(name)-len(archiveTimeLayout):], time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return info.ModTime(), nil
+}
+
+// List the archives of a Saves directory, the oldest first.
+// They are sorted by time, their names start with the name of their results directory.
+func Archives(directory string) ([]string, error) {
+	archives, err := filepath.Glob(filepath.Join(directory, "*.zip"))
+	if err != nil {
+		return nil, err
+	}
+
+	times := make(map[string]time.Time, len(archives))
+	for _, archive := range archives {
+		if times[archive], err = archiveTime(archive); err != nil {
+			return nil, err
+		}
+	}
+	sort.SliceStable(archives, func(i, j int) bool {
+		return times[archives[i]].Before(times[archives[j]])
+	})
+	return archives, nil
+}
+
+func extract(archivePath, directory string) error {
+	archive, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return err
+	}
+	defer archive.Close()
+
+	// The archives of older versions record the directories as empty files
+	dirs := make(map[string]bool)
+	for _, file := range archive.File {
+		for dir := path.Dir(file.Name); dir != "." && dir != "/"; dir = path.Dir(dir) {
+			dirs[dir] = true
+		}
+	}
+
+	for _, file := range archive.File {
+		name := strings.TrimSuffix(file.Name, "/")
+		if !filepath.IsLocal(filepath.FromSlash(name)) {
+			return fmt.Errorf("invalid path %s", file.Name)
+		}
+		target := filepath.Join(directory, filepath.FromSlash(name))
+
+		if strings.HasSuffix(file.Name, "/") || dirs[name] {
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		if err := extractFile(file, target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func extractFile(file *zip.File, target string) error {
+	reader, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	writer, err := os.Create(target)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(writer, reader); err != nil {
+		writer.Close()
+		return err
+	}
+	return writer.Close()
+}
@@ -0,0 +1,170 @@
+package store
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/colussim/GoLC/pkg/report"
+)
+
+// Code lines of the projects, repositories or languages at each run, by level of detail
+var historyQueries = map[string]string{
+	report.HistoryProject: `SELECT p.run_id, p.project, SUM(p.code_lines) FROM repositories p JOIN runs r ON r.id = p.run_id
+		WHERE r.organization = ? GROUP BY p.run_id, p.project`,
+	report.HistoryRepository: `SELECT p.run_id, p.project, p.repository, p.code_lines FROM repositories p JOIN runs r ON r.id = p.run_id
+		WHERE r.organization = ?`,
+	report.HistoryLanguage: `SELECT p.run_id, l.language, SUM(l.code_lines) FROM languages l JOIN repositories p ON p.id = l.repository_id JOIN runs r ON r.id = p.run_id
+		WHERE r.organization = ? GROUP BY p.run_id, l.language`,
+}
+
+// List the organizations of the runs, the latest run first
+func (s *Store) Organizations() ([]string, error) {
+	rows, err := s.db.Query(`SELECT organization FROM runs GROUP BY organization ORDER BY MAX(time) DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	organizations := []string{}
+	for rows.Next() {
+		var organization string
+		if err := rows.Scan(&organization); err != nil {
+			return nil, err
+		}
+		organizations = append(organizations, organization)
+	}
+	return organizations, rows.Err()
+}
+
+// History of the runs of an organization, the organization of the latest run when it is empty.
+// The series of its projects, repositories or languages are added with by, none when it is empty.
+func (s *Store) History(organization, by string) (*report.History, error) {
+	if by != "" && historyQueries[by] == "" {
+		return nil, fmt.Errorf("unknown level of detail %q, expected %s, %s or %s", by, report.HistoryProject, report.HistoryRepository, report.HistoryLanguage)
+	}
+
+	if organization == "" {
+		organizations, err := s.Organizations()
+		if err != nil {
+			return nil, err
+		}
+		if len(organizations) > 0 {
+			organization = organizations[0]
+		}
+	}
+
+	history := &report.History{Organization: organization, By: by, Runs: []report.RunRecord{}, Series: []report.HistorySerie{}, Changes: []report.RunChanges{}}
+
+	rows, err := s.db.Query(`SELECT id, time, platform, organization, repositories, code_lines FROM runs WHERE organization = ? ORDER BY time, id`, organization)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// Index of each run in the history
+	index := make(map[int64]int)
+	for rows.Next() {
+		var run report.RunRecord
+		var runTime string
+		if err := rows.Scan(&run.ID, &runTime, &run.Platform, &run.Organization, &run.Repositories, &run.CodeLines); err != nil {
+			return nil, err
+		}
+		if run.Time, err = time.Parse(time.RFC3339, runTime); err != nil {
+			return nil, err
+		}
+		index[run.ID] = len(history.Runs)
+		history.Runs = append(history.Runs, run)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	repos, analysed, err := s.historySeries(report.HistoryRepository, organization, index)
+	if err != nil {
+		return nil, err
+	}
+	history.Changes = runChanges(history.Runs, analysed)
+
+	switch by {
+	case "":
+	case report.HistoryRepository:
+		history.Series = repos
+	default:
+		if history.Series, _, err = s.historySeries(by, organization, index); err != nil {
+			return nil, err
+		}
+	}
+
+	return history, nil
+}
+
+// Read the series of a level of detail, the largest at the last run first,
+// with the runs in which each name was analysed
+func (s *Store) historySeries(by, organization string, index map[int64]int) ([]report.HistorySerie, map[string][]bool, error) {
+	rows, err := s.db.Query(historyQueries[by], organization)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	series := make(map[string][]int)
+	analysed := make(map[string][]bool)
+	for rows.Next() {
+		var runID int64
+		var name string
+		var codeLines int
+		if by == report.HistoryRepository {
+			var project string
+			err = rows.Scan(&runID, &project, &name, &codeLines)
+			name = report.HistoryRepoName(project, name)
+		} else {
+			err = rows.Scan(&runID, &name, &codeLines)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if series[name] == nil {
+			series[name] = make([]int, len(index))
+			analysed[name] = make([]bool, len(index))
+		}
+		series[name][index[runID]] += codeLines
+		analysed[name][index[runID]] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	result := make([]report.HistorySerie, 0, len(series))
+	for name, codeLines := range series {
+		result = append(result, report.HistorySerie{Name: name, CodeLines: codeLines})
+	}
+	last := len(index) - 1
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].CodeLines[last] != result[j].CodeLines[last] {
+			return result[i].CodeLines[last] > result[j].CodeLines[last]
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result, analysed, nil
+}
+
+// Repositories added and removed at each run since the previous one
+func runChanges(runs []report.RunRecord, analysed map[string][]bool) []report.RunChanges {
+	changes := []report.RunChanges{}
+	for i := 1; i < len(runs); i++ {
+		change := report.RunChanges{RunID: runs[i].ID, Added: []string{}, Removed: []string{}}
+		for name, in := range analysed {
+			if in[i] && !in[i-1] {
+				change.Added = append(change.Added, name)
+			} else if in[i-1] && !in[i] {
+				change.Removed = append(change.Removed, name)
+			}
+		}
+		sort.Strings(change.Added)
+		sort.Strings(change.Removed)
+		changes = append(changes, change)
+	}
+	return changes
+}
@@ -0,0 +1,206 @@
+// Package store records the results of every run in a SQLite database,
+// to query the growth of the code over time
+package store
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/colussim/GoLC/pkg/report"
+
+	// Pure Go driver, no cgo needed
+	_ "modernc.org/sqlite"
+)
+
+const schema = `
+CREATE TABLE IF NOT EXISTS runs (
+	id            INTEGER PRIMARY KEY AUTOINCREMENT,
+	time          TEXT NOT NULL,
+	platform      TEXT NOT NULL,
+	organization  TEXT NOT NULL,
+	repositories  INTEGER NOT NULL,
+	code_lines    INTEGER NOT NULL,
+	UNIQUE (time, platform, organization)
+);
+CREATE TABLE IF NOT EXISTS repositories (
+	id           INTEGER PRIMARY KEY AUTOINCREMENT,
+	run_id       INTEGER NOT NULL REFERENCES runs(id) ON DELETE CASCADE,
+	project      TEXT NOT NULL,
+	repository   TEXT NOT NULL,
+	branch       TEXT NOT NULL,
+	commit_sha   TEXT NOT NULL,
+	files        INTEGER NOT NULL,
+	lines        INTEGER NOT NULL,
+	blank_lines  INTEGER NOT NULL,
+	comments     INTEGER NOT NULL,
+	code_lines   INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS repositories_run ON repositories(run_id);
+CREATE TABLE IF NOT EXISTS languages (
+	repository_id INTEGER NOT NULL REFERENCES repositories(id) ON DELETE CASCADE,
+	language      TEXT NOT NULL,
+	files         INTEGER NOT NULL,
+	lines         INTEGER NOT NULL,
+	blank_lines   INTEGER NOT NULL,
+	comments      INTEGER NOT NULL,
+	code_lines    INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS languages_repository ON languages(repository_id);
+CREATE VIEW IF NOT EXISTS metrics AS
+	SELECT r.id AS run_id, r.time, r.platform, r.organization,
+		p.project, p.repository, p.branch, p.commit_sha,
+		l.language, l.files, l.lines, l.blank_lines, l.comments, l.code_lines
+	FROM runs r
+	JOIN repositories p ON p.run_id = r.id
+	JOIN languages l ON l.repository_id = p.id;
+`
+
+// Error of a run which is already in the database
+var ErrRecorded = errors.New("run already recorded")
+
+type Store struct {
+	db *sql.DB
+}
+
+// A run of GoLC on an organization
+type Run struct {
+	Time         time.Time
+	Platform     string
+	Organization string
+}
+
+// Open the database, created with its tables if it does not exist
+func Open(path string) (*Store, error) {
+	// The pragmas are set in the DSN so that every connection of the pool has them
+	db, err := sql.Open("sqlite", path+"?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("error creating the tables of %s: %w", path, err)
+	}
+	return &Store{db: db}, nil
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Save a run with the results of its repositories and return its id
+func (s *Store) SaveRun(run Run, repos []report.RepoData) (int64, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	runTime := run.Time.UTC().Format(time.RFC3339)
+	var existing int64
+	err = tx.QueryRow(`SELECT id FROM runs WHERE time = ? AND platform = ? AND organization = ?`, runTime, run.Platform, run.Organization).Scan(&existing)
+	if err == nil {
+		return existing, ErrRecorded
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return 0, err
+	}
+
+	codeLines := 0
+	for _, repo := range repos {
+		codeLines += repo.TotalCodeLines
+	}
+
+	res, err := tx.Exec(`INSERT INTO runs (time, platform, organization, repositories, code_lines) VALUES (?, ?, ?, ?, ?)`,
+		runTime, run.Platform, run.Organization, len(repos), codeLines)
+	if err != nil {
+		return 0, err
+	}
+	runID, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	for _, repo := range repos {
+		res, err := tx.Exec(`INSERT INTO repositories (run_id, project, repository, branch, commit_sha, files, lines, blank_lines, comments, code_lines) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			runID, repo.Project, repo.Repository, repo.Branch, repo.Commit, repo.TotalFiles, repo.TotalLines, repo.TotalBlankLines, repo.TotalComments, repo.TotalCodeLines)
+		if err != nil {
+			return 0, err
+		}
+		repoID, err := res.LastInsertId()
+		if err != nil {
+			return 0, err
+		}
+
+		for _, lang := range repo.Results {
+			_, err := tx.Exec(`INSERT INTO languages (repository_id, language, files, lines, blank_lines, comments, code_lines) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+				repoID, lang.Language, lang.Files, lang.Lines, lang.BlankLines, lang.Comments, lang.CodeLines)
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return runID, tx.Commit()
+}
+
+// Save the results directory of a run. The time of the run is its start recorded in
+// config/run.json, or the time of its GlobalReport.json file for the results of older versions.
+func (s *Store) SaveResults(directory string) (int64, error) {
+	info, err := os.Stat(filepath.Join(directory, "GlobalReport.json"))
+	if err != nil {
+		return 0, err
+	}
+	return s.saveDirectory(directory, info.ModTime())
+}
+
+// Save the results directory of a run, runTime is used when the start of the run was not recorded
+func (s *Store) saveDirectory(directory string, runTime time.Time) (int64, error) {
+	Ginfo, err := report.LoadGlobalReport(directory)
+	if err != nil {
+		return 0, err
+	}
+	run, err := report.LoadRunInfo(directory)
+	if err != nil {
+		return 0, err
+	}
+	if !run.Start.IsZero() {
+		runTime = run.Start
+	}
+	repos, err := report.LoadRepos(directory)
+	if err != nil {
+		return 0, err
+	}
+
+	return s.SaveRun(Run{
+		Time:         runTime,
+		Platform:     Ginfo.DevOpsPlatform,
+		Organization: Ginfo.Organization,
+	}, repos)
+}
+
+// List the runs, the latest first
+func (s *Store) Runs() ([]report.RunRecord, error) {
+	rows, err := s.db.Query(`SELECT id, time, platform, organization, repositories, code_lines FROM runs ORDER BY time DESC, id DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	runs := []report.RunRecord{}
+	for rows.Next() {
+		var run report.RunRecord
+		var runTime string
+		if err := rows.Scan(&run.ID, &runTime, &run.Platform, &run.Organization, &run.Repositories, &run.CodeLines); err != nil {
+			return nil, err
+		}
+		if run.Time, err = time.Parse(time.RFC3339, runTime); err != nil {
+			return nil, err
+		}
+		runs = append(runs, run)
+	}
+	return runs, rows.Err()
+}
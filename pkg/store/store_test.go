@@ -0,0 +1,256 @@
+package store
+
+import (
+	"archive/zip"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/colussim/GoLC/pkg/report"
+)
+
+func openStore(t *testing.T) *Store {
+	t.Helper()
+	s, err := Open(filepath.Join(t.TempDir(), "golc.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+// Repository with its code lines by language
+func repoData(project, repository string, codeLines map[string]int) report.RepoData {
+	repo := report.RepoData{Project: project, Repository: repository, Branch: "main"}
+	for language, lines := range codeLines {
+		repo.Results = append(repo.Results, report.LanguageResult{Language: language, CodeLines: lines})
+		repo.TotalCodeLines += lines
+	}
+	return repo
+}
+
+func TestSaveRunRecorded(t *testing.T) {
+	s := openStore(t)
+	run := Run{Time: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), Platform: "Github", Organization: "acme"}
+	repos := []report.RepoData{repoData("", "api", map[string]int{"Go": 100})}
+
+	id, err := s.SaveRun(run, repos)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The same run in another time zone is the same run
+	run.Time = run.Time.In(time.FixedZone("CET", 3600))
+	again, err := s.SaveRun(run, repos)
+	if !errors.Is(err, ErrRecorded) || again != id {
+		t.Errorf("SaveRun() again = %d, %v, want %d, %v", again, err, id, ErrRecorded)
+	}
+
+	runs, err := s.Runs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(runs) != 1 || runs[0].Repositories != 1 || runs[0].CodeLines != 100 {
+		t.Errorf("runs %+v, want one run of 1 repository and 100 code lines", runs)
+	}
+}
+
+func TestHistory(t *testing.T) {
+	s := openStore(t)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	runs := [][]report.RepoData{
+		{repoData("PRJ", "api", map[string]int{"Go": 100}), repoData("PRJ", "web", map[string]int{"JavaScript": 50})},
+		{repoData("PRJ", "api", map[string]int{"Go": 120, "Shell": 10}), repoData("OPS", "tools", map[string]int{"Go": 30})},
+		{repoData("PRJ", "api", map[string]int{"Go": 150}), repoData("OPS", "tools", map[string]int{"Go": 30})},
+	}
+	for i, repos := range runs {
+		if _, err := s.SaveRun(Run{Time: start.AddDate(0, 0, i), Platform: "Github", Organization: "acme"}, repos); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Latest run of another organization, it is the default one
+	if _, err := s.SaveRun(Run{Time: start.AddDate(0, 1, 0), Platform: "Gitlab", Organization: "globex"}, []report.RepoData{repoData("", "lib", map[string]int{"C": 10})}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		organization string
+		by           string
+		codeLines    []int
+		series       []report.HistorySerie
+	}{
+		{"runs", "acme", "", []int{150, 160, 180}, []report.HistorySerie{}},
+		{"projects", "acme", report.HistoryProject, []int{150, 160, 180}, []report.HistorySerie{
+			{Name: "PRJ", CodeLines: []int{150, 130, 150}},
+			{Name: "OPS", CodeLines: []int{0, 30, 30}},
+		}},
+		{"repositories", "acme", report.HistoryRepository, []int{150, 160, 180}, []report.HistorySerie{
+			{Name: "PRJ/api", CodeLines: []int{100, 130, 150}},
+			{Name: "OPS/tools", CodeLines: []int{0, 30, 30}},
+			{Name: "PRJ/web", CodeLines: []int{50, 0, 0}},
+		}},
+		{"languages", "acme", report.HistoryLanguage, []int{150, 160, 180}, []report.HistorySerie{
+			{Name: "Go", CodeLines: []int{100, 150, 180}},
+			{Name: "JavaScript", CodeLines: []int{50, 0, 0}},
+			{Name: "Shell", CodeLines: []int{0, 10, 0}},
+		}},
+		{"latest organization", "", report.HistoryRepository, []int{10}, []report.HistorySerie{
+			{Name: "lib", CodeLines: []int{10}},
+		}},
+		{"unknown organization", "initech", "", []int{}, []report.HistorySerie{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			history, err := s.History(tt.organization, tt.by)
+			if err != nil {
+				t.Fatal(err)
+			}
+			codeLines := []int{}
+			for _, run := range history.Runs {
+				codeLines = append(codeLines, run.CodeLines)
+			}
+			if !reflect.DeepEqual(codeLines, tt.codeLines) {
+				t.Errorf("code lines of the runs %v, want %v", codeLines, tt.codeLines)
+			}
+			if !reflect.DeepEqual(history.Series, tt.series) {
+				t.Errorf("series %+v, want %+v", history.Series, tt.series)
+			}
+		})
+	}
+
+	history, err := s.History("acme", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	changes := []report.RunChanges{
+		{RunID: history.Runs[1].ID, Added: []string{"OPS/tools"}, Removed: []string{"PRJ/web"}},
+		{RunID: history.Runs[2].ID, Added: []string{}, Removed: []string{}},
+	}
+	if !reflect.DeepEqual(history.Changes, changes) {
+		t.Errorf("changes %+v, want %+v", history.Changes, changes)
+	}
+
+	if _, err := s.History("acme", "file"); err == nil {
+		t.Error("History() by file succeeded")
+	}
+}
+
+// Write a zip archive of files, a name ending with "/" is a directory
+func writeArchive(t *testing.T, path string, files map[string]string) {
+	t.Helper()
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	w := zip.NewWriter(f)
+	for name, content := range files {
+		fw, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := fw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSaveArchive(t *testing.T) {
+	global := `{"Organization": "acme", "DevOpsPlatform": "Github"}`
+	result := `{"TotalCodeLines": 100, "Results": [{"Language": "Go", "CodeLines": 100}]}`
+	run := `{"Start": "2024-03-04T05:06:07Z"}`
+
+	tests := []struct {
+		name  string
+		file  string
+		files map[string]string
+		time  time.Time
+		fails bool
+	}{
+		{
+			name:  "time of the name",
+			file:  "Results_2024-01-02_03-04-05.zip",
+			files: map[string]string{"config/": "", "GlobalReport.json": global, "Result_api_main.json": result},
+			time:  time.Date(2024, 1, 2, 3, 4, 5, 0, time.Local),
+		},
+		{
+			// The archives of older versions record the directories as empty files
+			name:  "directories as files",
+			file:  "Results_2024-01-03_03-04-05.zip",
+			files: map[string]string{"config": "", "config/run.json": "{}", "GlobalReport.json": global, "Result_api_main.json": result},
+			time:  time.Date(2024, 1, 3, 3, 4, 5, 0, time.Local),
+		},
+		{
+			name:  "start of the run",
+			file:  "Results_2024-01-04_03-04-05.zip",
+			files: map[string]string{"GlobalReport.json": global, "Result_api_main.json": result, "config/run.json": run},
+			time:  time.Date(2024, 3, 4, 5, 6, 7, 0, time.UTC),
+		},
+		{
+			name:  "path outside the directory",
+			file:  "Results_2024-01-05_03-04-05.zip",
+			files: map[string]string{"../GlobalReport.json": global},
+			fails: true,
+		},
+	}
+
+	dir := t.TempDir()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := openStore(t)
+			archive := filepath.Join(dir, tt.file)
+			writeArchive(t, archive, tt.files)
+
+			_, err := s.SaveArchive(archive)
+			if tt.fails {
+				if err == nil {
+					t.Error("SaveArchive() succeeded")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			runs, err := s.Runs()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(runs) != 1 {
+				t.Fatalf("%d runs, want 1", len(runs))
+			}
+			if !runs[0].Time.Equal(tt.time) || runs[0].Organization != "acme" || runs[0].CodeLines != 100 {
+				t.Errorf("run %+v, want acme at %s with 100 code lines", runs[0], tt.time)
+			}
+		})
+	}
+}
+
+func TestArchives(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"Results_2024-02-01_00-00-00.zip", "Results_2023-12-31_23-59-59.zip", "Results_2024-01-15_12-00-00.zip", "notes.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	archives, err := Archives(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		filepath.Join(dir, "Results_2023-12-31_23-59-59.zip"),
+		filepath.Join(dir, "Results_2024-01-15_12-00-00.zip"),
+		filepath.Join(dir, "Results_2024-02-01_00-00-00.zip"),
+	}
+	if !reflect.DeepEqual(archives, want) {
+		t.Errorf("Archives() = %v, want %v", archives, want)
+	}
+}
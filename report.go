@@ -0,0 +1,318 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/colussim/GoLC/pkg/diff"
+	"github.com/colussim/GoLC/pkg/report"
+	"github.com/colussim/GoLC/pkg/store"
+)
+
+// Options of the report and serve commands
+type reportOptions struct {
+	results  string
+	pdf      bool
+	branding report.PDFOptions
+	serve    bool
+	addr     string
+	dist     string
+	metrics  bool
+	db       string
+	tlsCert  string
+	tlsKey   string
+	tlsSelf  bool
+	auth     report.Auth
+}
+
+// Add the flags of the web server to a command
+func addServeFlags(fs *flag.FlagSet, opts *reportOptions) *int {
+	fs.StringVar(&opts.addr, "addr", ":8080", "Listen address of the web server, like :8080 or 127.0.0.1:8080")
+	portFlag := fs.Int("port", 0, "Port of the web server, shortcut for -addr :<port>")
+	fs.StringVar(&opts.dist, "dist", "", "Directory of the web assets, the assets embedded in golc without it")
+	fs.BoolVar(&opts.metrics, "metrics", false, "Also expose the latest results as OpenMetrics on /metrics")
+	fs.StringVar(&opts.db, "db", "", "SQLite database of the runs listed by /api/runs, only the last run of the results directory without it")
+	fs.StringVar(&opts.tlsCert, "tls-cert", "", "Certificate file, to serve over HTTPS with -tls-key")
+	fs.StringVar(&opts.tlsKey, "tls-key", "", "Private key file of the certificate")
+	fs.BoolVar(&opts.tlsSelf, "tls-self-signed", false, "Serve over HTTPS with a self-signed certificate generated at startup, for development")
+	fs.Func("basic-auth", "Require the basic auth credentials USER:PASSWORD, default $GOLC_BASIC_AUTH", func(s string) error {
+		return setBasicAuth(&opts.auth, s)
+	})
+	fs.StringVar(&opts.auth.Token, "token", "", "Require the bearer token, default $GOLC_TOKEN")
+	if s := os.Getenv("GOLC_BASIC_AUTH"); s != "" {
+		if err := setBasicAuth(&opts.auth, s); err != nil {
+			fmt.Println("❌ GOLC_BASIC_AUTH:", err)
+			os.Exit(1)
+		}
+	}
+	return portFlag
+}
+
+// Read the bearer token from $GOLC_TOKEN when -token is not given.
+// It is not the default of the flag so that the help does not print it.
+func tokenFromEnv(fs *flag.FlagSet, opts *reportOptions) {
+	set := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == "token" {
+			set = true
+		}
+	})
+	if !set {
+		opts.auth.Token = os.Getenv("GOLC_TOKEN")
+	}
+}
+
+// Parse the credentials USER:PASSWORD
+func setBasicAuth(auth *report.Auth, s string) error {
+	user, password, ok := strings.Cut(s, ":")
+	if !ok || user == "" {
+		return fmt.Errorf("expected USER:PASSWORD")
+	}
+	auth.User, auth.Password = user, password
+	return nil
+}
+
+func reportCommand(args []string) {
+	var opts reportOptions
+	fs := newFlagSet("report", "", "Generate the PDF report of a results directory and optionally start its web visualization")
+	resultsFlag := addOutputFlag(fs)
+	fs.BoolVar(&opts.pdf, "pdf", true, "Generate <results>/GlobalReport.pdf")
+	fs.StringVar(&opts.branding.Title, "pdf-title", "GoLC Report", "Title of the PDF report")
+	fs.StringVar(&opts.branding.Logo, "pdf-logo", "", "PNG or JPEG logo of the PDF report, the GoLC logo without it")
+	fs.StringVar(&opts.branding.Color, "pdf-color", "#3399ff", "Main color of the PDF report, as #rrggbb")
+	fs.IntVar(&opts.branding.Top, "pdf-top", 10, "Number of largest repositories listed in the PDF report")
+	fs.BoolVar(&opts.serve, "serve", false, "Start the web visualization once the report is generated")
+	portFlag := addServeFlags(fs, &opts)
+
+	positional := parseArgs(fs, args)
+	requireArgs(fs, positional, 0)
+	opts.results = *resultsFlag
+	tokenFromEnv(fs, &opts)
+	if *portFlag != 0 {
+		opts.addr = fmt.Sprintf(":%d", *portFlag)
+	}
+
+	runReport(opts)
+}
+
+func serveCommand(args []string) {
+	opts := reportOptions{serve: true}
+	fs := newFlagSet("serve", "", "Start the web visualization of a results directory, same as report -pdf=false -serve")
+	resultsFlag := addOutputFlag(fs)
+	portFlag := addServeFlags(fs, &opts)
+
+	positional := parseArgs(fs, args)
+	requireArgs(fs, positional, 0)
+	opts.results = *resultsFlag
+	tokenFromEnv(fs, &opts)
+	if *portFlag != 0 {
+		opts.addr = fmt.Sprintf(":%d", *portFlag)
+	}
+
+	runReport(opts)
+}
+
+func runReport(opts reportOptions) {
+	pageData, err := report.Load(opts.results)
+	if err != nil {
+		fmt.Println("❌", err)
+		os.Exit(1)
+	}
+	fmt.Println("✅ Results analysis recorded in", filepath.Join(opts.results, "code_lines_by_language.json"))
+
+	if opts.pdf {
+		opts.branding.Version = version
+		if err := report.GeneratePDF(opts.results, pageData, opts.branding); err != nil {
+			fmt.Println("❌ Error saving PDF file:", err)
+			os.Exit(1)
+		}
+		fmt.Println("✅ PDF generated successfully!")
+	}
+
+	if !opts.serve {
+		return
+	}
+
+	tlsConfig, err := report.TLSConfig(opts.tlsCert, opts.tlsKey, opts.tlsSelf, opts.addr)
+	if err != nil {
+		fmt.Println("❌ Error loading TLS certificate:", err)
+		os.Exit(1)
+	}
+	if opts.tlsSelf && tlsConfig != nil && opts.tlsCert == "" {
+		fmt.Println("❗️ Self-signed certificate, browsers will show a warning : use -tls-cert and -tls-key outside development")
+	}
+	if opts.auth.Enabled() && tlsConfig == nil {
+		fmt.Println("❗️ The credentials are sent in clear text without TLS")
+	}
+
+	mux := report.Handler(pageData, opts.dist)
+
+	api := &report.API{Directory: opts.results}
+	if opts.db != "" {
+		db, err := store.Open(opts.db)
+		if err != nil {
+			fmt.Println("❌ Error opening database:", err)
+			os.Exit(1)
+		}
+		defer db.Close()
+		api.Runs = db.Runs
+		api.History = db.History
+		report.RegisterHistoryPage(mux, db.Organizations, db.History)
+	} else {
+		report.RegisterHistoryPage(mux, nil, nil)
+	}
+	api.Register(mux)
+	report.RegisterRepoPages(mux, opts.results)
+	url := report.ServerURL(opts.addr, tlsConfig != nil)
+	fmt.Printf("✅ JSON API available on %s/api/\n", url)
+
+	if opts.metrics {
+		mux.Handle("/metrics", report.MetricsHandler(opts.results))
+		fmt.Printf("✅ Metrics exposed on %s/metrics\n", url)
+	}
+
+	fmt.Println("✅ Launching web visualization...")
+	if err := report.StartServer(opts.addr, opts.auth.Handler(mux), tlsConfig); err != nil {
+		fmt.Println("❌ Error starting server:", err)
+		os.Exit(1)
+	}
+}
+
+func htmlCommand(args []string) {
+	fs := newFlagSet("html", "", "Write the report of a results directory as a single HTML file, readable offline")
+	outputFlag := addOutputFlag(fs)
+	fileFlag := fs.String("file", "", "Path of the HTML file, default <output>/GlobalReport.html")
+
+	positional := parseArgs(fs, args)
+	requireArgs(fs, positional, 0)
+
+	pageData, err := report.Load(*outputFlag)
+	if err != nil {
+		fmt.Println("❌", err)
+		os.Exit(1)
+	}
+
+	repos, err := report.LoadRepos(*outputFlag)
+	if err != nil {
+		fmt.Println("❌ Error reading repository results:", err)
+		os.Exit(1)
+	}
+
+	path := *fileFlag
+	if path == "" {
+		path = filepath.Join(*outputFlag, "GlobalReport.html")
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		fmt.Println("❌ Error creating file:", err)
+		os.Exit(1)
+	}
+	defer file.Close()
+
+	if err := report.WriteHTML(file, pageData, repos); err != nil {
+		fmt.Println("❌ Error writing HTML report:", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("✅ HTML report generated in %s\n", path)
+}
+
+func markdownCommand(args []string) {
+	fs := newFlagSet("markdown", "", "Write the markdown summary of a results directory, for pull requests and wikis")
+	outputFlag := addOutputFlag(fs)
+	fileFlag := fs.String("file", "", "Path of the markdown file, - for stdout, default <output>/GlobalReport.md")
+	topFlag := fs.Int("top", 10, "Number of repositories listed, 0 for all")
+	baselineFlag := fs.String("baseline", "", "Results directory of a previous run, to add the changes since this run")
+
+	positional := parseArgs(fs, args)
+	requireArgs(fs, positional, 0)
+
+	pageData, err := report.Load(*outputFlag)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "❌", err)
+		os.Exit(1)
+	}
+
+	repos, err := report.LoadRepos(*outputFlag)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "❌ Error reading repository results:", err)
+		os.Exit(1)
+	}
+
+	var baseline *diff.Report
+	if *baselineFlag != "" {
+		baseline, err = report.CompareDirs(*baselineFlag, *outputFlag)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "❌ Error comparing results:", err)
+			os.Exit(1)
+		}
+	}
+
+	if *fileFlag == "-" {
+		if err := report.WriteMarkdown(os.Stdout, pageData, repos, *topFlag, baseline); err != nil {
+			fmt.Fprintln(os.Stderr, "❌ Error writing markdown summary:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	path := *fileFlag
+	if path == "" {
+		path = filepath.Join(*outputFlag, "GlobalReport.md")
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		fmt.Println("❌ Error creating file:", err)
+		os.Exit(1)
+	}
+	defer file.Close()
+
+	if err := report.WriteMarkdown(file, pageData, repos, *topFlag, baseline); err != nil {
+		fmt.Println("❌ Error writing markdown summary:", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("✅ Markdown summary generated in %s\n", path)
+}
+
+func metricsCommand(args []string) {
+	fs := newFlagSet("metrics", "", "Write the OpenMetrics file of a results directory, for the textfile collector of the node exporter")
+	outputFlag := addOutputFlag(fs)
+	fileFlag := fs.String("file", "", "Path of the metrics file, default <output>/metrics.prom")
+
+	positional := parseArgs(fs, args)
+	requireArgs(fs, positional, 0)
+
+	path := *fileFlag
+	if path == "" {
+		path = filepath.Join(*outputFlag, "metrics.prom")
+	}
+
+	// The collector may read the file at any time, it is replaced once complete
+	tmp := path + ".tmp"
+	file, err := os.Create(tmp)
+	if err != nil {
+		fmt.Println("❌ Error creating file:", err)
+		os.Exit(1)
+	}
+
+	err = report.WriteMetrics(file, *outputFlag)
+	if cerr := file.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp, path)
+	}
+	if err != nil {
+		os.Remove(tmp)
+		fmt.Println("❌ Error writing metrics:", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("✅ Metrics written to %s\n", path)
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/colussim/GoLC/pkg/store"
+)
+
+// Record the results directory of a run in the SQLite database
+func saveRun(database, DestinationResult string) error {
+	db, err := store.Open(database)
+	if err != nil {
+		fmt.Println("\n❌ Error opening database:", err)
+		return err
+	}
+	defer db.Close()
+
+	runID, err := db.SaveResults(DestinationResult)
+	if errors.Is(err, store.ErrRecorded) {
+		fmt.Printf("\n❗️ Run %d of %s is already recorded in %s\n", runID, DestinationResult, database)
+		return nil
+	}
+	if err != nil {
+		fmt.Println("\n❌ Error recording the run in the database:", err)
+		return err
+	}
+
+	fmt.Printf("\n✅ Run %d recorded in %s\n", runID, database)
+	return nil
+}
+
+func storeCommand(args []string) {
+	fs := newFlagSet("store", "", "Record the results directory of a run in a SQLite database")
+	outputFlag := addOutputFlag(fs)
+	dbFlag := fs.String("db", "golc.db", "Path of the SQLite database")
+	savesFlag := fs.String("saves", "", "Also record the runs archived in this directory, like Saves")
+
+	positional := parseArgs(fs, args)
+	requireArgs(fs, positional, 0)
+
+	if *savesFlag != "" {
+		if err := saveArchives(*dbFlag, *savesFlag); err != nil {
+			os.Exit(1)
+		}
+	}
+
+	if err := saveRun(*dbFlag, *outputFlag); err != nil {
+		os.Exit(1)
+	}
+}
+
+// Record the runs archived in a Saves directory, the oldest first
+func saveArchives(database, directory string) error {
+	archives, err := store.Archives(directory)
+	if err != nil {
+		fmt.Println("❌ Error listing archives:", err)
+		return err
+	}
+
+	db, err := store.Open(database)
+	if err != nil {
+		fmt.Println("❌ Error opening database:", err)
+		return err
+	}
+	defer db.Close()
+
+	for _, archive := range archives {
+		runID, err := db.SaveArchive(archive)
+		if errors.Is(err, store.ErrRecorded) {
+			fmt.Printf("❗️ Run %d of %s is already recorded in %s\n", runID, archive, database)
+			continue
+		}
+		if err != nil {
+			// An archive may be incomplete, the other runs are still recorded
+			fmt.Printf("❌ Error recording %s: %s\n", archive, err)
+			continue
+		}
+		fmt.Printf("✅ Run %d of %s recorded in %s\n", runID, archive, database)
+	}
+	return nil
+}